@@ -0,0 +1,48 @@
+package piiredact
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// defaultAgeThreshold is the highest age AGE leaves alone: HIPAA Safe
+// Harbor treats ages over 89 as identifying.
+const defaultAgeThreshold = 89
+
+// ageRegex matches an age stated as "92 years old", "92-year-old",
+// "92 yo", "92 y/o", "age 92" or "aged 92", capturing the number.
+var ageRegex = regexp.MustCompile(`(?i)\b(?:aged?:?\s*(\d{1,3})\b|(\d{1,3})[- ]?(?:years?|yrs?)[- ]?old\b|(\d{1,3})\s*(?:y/o|yo\b))`)
+
+// findAges returns the span of the number in each age phrase, so only the
+// number is generalized: "92 years old" becomes "90+ years old".
+func findAges(text string) [][]int {
+	var spans [][]int
+	for _, m := range ageRegex.FindAllStringSubmatchIndex(text, -1) {
+		for g := 2; g < len(m); g += 2 {
+			if m[g] >= 0 {
+				spans = append(spans, []int{m[g], m[g+1]})
+				break
+			}
+		}
+	}
+	return spans
+}
+
+// agePattern returns the AGE pattern for the given threshold. Ages above
+// it are generalized to "<threshold+1>+", such as "90+", whatever the
+// engine's Mode; ages at or below it are not matched at all.
+func agePattern(p PatternDef, threshold int) PatternDef {
+	if threshold <= 0 {
+		threshold = defaultAgeThreshold
+	}
+	p.Validate = func(value string) bool {
+		age, err := strconv.Atoi(value)
+		return err == nil && age > threshold
+	}
+	generalized := strconv.Itoa(threshold+1) + "+"
+	p.rewrite = func(value string, r *redaction) string {
+		r.counts[p.Name]++
+		return generalized
+	}
+	return p
+}
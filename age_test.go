@@ -0,0 +1,54 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_Age tests generalizing ages above the threshold
+func TestRedactionEngine_Age(t *testing.T) {
+	testCases := []struct {
+		threshold int
+		input     string
+		expected  string
+	}{
+		{0, "Patient is 92 years old", "Patient is 90+ years old"},
+		{0, "a 95-year-old woman", "a 90+-year-old woman"},
+		{0, "Age: 91, admitted", "Age: 90+, admitted"},
+		{0, "aged 100 at death", "aged 90+ at death"},
+		{0, "90 y/o male", "90+ y/o male"},
+		// The threshold itself and younger ages are left alone
+		{0, "Patient is 89 years old", "Patient is 89 years old"},
+		{0, "age 45", "age 45"},
+		// Numbers that are not stated as ages are not matched
+		{0, "room 92 on floor 3", "room 92 on floor 3"},
+		{0, "page 120", "page 120"},
+		// Configurable threshold
+		{79, "Patient is 80 years old", "Patient is 80+ years old"},
+		{79, "Patient is 79 years old", "Patient is 79 years old"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.EnabledPatterns["AGE"] = true
+		config.AgeThreshold = tc.threshold
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Threshold %d: Input: %s\nExpected: %s\nGot: %s", tc.threshold, tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// AGE is opt-in and counted in metrics when enabled
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: "92 years old"}})
+	if result[0].Text != "92 years old" {
+		t.Errorf("Expected AGE to be disabled by default, got %q", result[0].Text)
+	}
+	config := DefaultConfig()
+	config.EnabledPatterns["AGE"] = true
+	engine := NewRedactionEngine(config)
+	engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "ages 92 years old and 95 yo"}})
+	if n := engine.GetMetrics().RedactedItems["AGE"]; n != 2 {
+		t.Errorf("Expected 2 AGE redactions, got %d", n)
+	}
+}
@@ -0,0 +1,77 @@
+package piiredact
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Aggressive matching for glued ASR output.
+//
+// Poor transcripts run words into values, as in "ssn123-45-6789email", so
+// \b never matches and the SSN is missed. With Config.AggressiveBoundaries
+// the \b assertions of selected patterns are removed and replaced by a
+// weaker check that only stops a match from starting or ending inside a
+// run of digits. False positives are then left to the patterns'
+// validators, which is why by default only patterns with a Validate
+// function are relaxed.
+
+// relaxBoundaries returns a copy of re with every \b assertion removed.
+func relaxBoundaries(re *regexp.Regexp) *regexp.Regexp {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return re
+	}
+	dropWordBoundaries(parsed)
+	relaxed, err := regexp.Compile(parsed.String())
+	if err != nil {
+		return re
+	}
+	return relaxed
+}
+
+// dropWordBoundaries replaces \b nodes in a parsed regex with empty matches.
+func dropWordBoundaries(re *syntax.Regexp) {
+	if re.Op == syntax.OpWordBoundary {
+		re.Op = syntax.OpEmptyMatch
+		return
+	}
+	for _, sub := range re.Sub {
+		dropWordBoundaries(sub)
+	}
+}
+
+// digitBoundary is the ContextValidate of relaxed patterns. It rejects a
+// match that starts or ends in the middle of a run of digits.
+func digitBoundary(text string, start, end int) bool {
+	if start > 0 {
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		prev, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsDigit(first) && unicode.IsDigit(prev) {
+			return false
+		}
+	}
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsDigit(last) && unicode.IsDigit(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// relaxPattern applies aggressive matching to p, keeping any existing
+// context check.
+func relaxPattern(p PatternDef) PatternDef {
+	p.Regex = relaxBoundaries(p.Regex)
+	if check := p.ContextValidate; check != nil {
+		p.ContextValidate = func(text string, start, end int) bool {
+			return digitBoundary(text, start, end) && check(text, start, end)
+		}
+	} else {
+		p.ContextValidate = digitBoundary
+	}
+	return p
+}
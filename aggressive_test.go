@@ -0,0 +1,57 @@
+package piiredact
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestRedactionEngine_AggressiveBoundaries tests values glued to words in ASR output
+func TestRedactionEngine_AggressiveBoundaries(t *testing.T) {
+	testCases := []struct {
+		input   string
+		strict  string
+		relaxed string
+	}{
+		{"ssn123-45-6789email user@x.com", "ssn123-45-6789email [EMAIL]", "ssn[SSN]email [EMAIL]"},
+		{"cardnumber4111111111111111thanks", "cardnumber4111111111111111thanks", "cardnumber[CC]thanks"},
+		{"itin912-70-1234ok", "itin912-70-1234ok", "itin[ITIN]ok"},
+		// Digits glued to digits are still not split, and validators still apply
+		{"order1123-45-67890", "order1123-45-67890", "order1123-45-67890"},
+		{"ref666-12-3456x", "ref666-12-3456x", "ref666-12-3456x"},
+	}
+
+	strict := NewRedactionEngine(DefaultConfig())
+	config := DefaultConfig()
+	config.AggressiveBoundaries = true
+	relaxed := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := strict.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.strict {
+			t.Errorf("Strict input: %s\nExpected: %s\nGot: %s", tc.input, tc.strict, result[0].Text)
+		}
+		result, _ = relaxed.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.relaxed {
+			t.Errorf("Relaxed input: %s\nExpected: %s\nGot: %s", tc.input, tc.relaxed, result[0].Text)
+		}
+	}
+
+	// Only the named patterns are relaxed
+	config.AggressivePatterns = []string{"CC"}
+	engine := NewRedactionEngine(config)
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "ssn123-45-6789 cc4111111111111111"}})
+	if expected := "ssn123-45-6789 cc[CC]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestRelaxBoundaries tests removing \b assertions from a regex
+func TestRelaxBoundaries(t *testing.T) {
+	re := relaxBoundaries(regexp.MustCompile(`\b\d{3}\b|x\\b`))
+	if !re.MatchString("a123b") {
+		t.Errorf("Relaxed regex %s should match glued digits", re)
+	}
+	if !re.MatchString(`x\b`) {
+		t.Errorf("Relaxed regex %s should keep escaped backslashes", re)
+	}
+}
@@ -0,0 +1,131 @@
+package piiredact
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// acNode is a single state of the Aho-Corasick automaton.
+type acNode struct {
+	next map[rune]int32 // Goto transitions keyed by lower-cased rune
+	fail int32          // Failure link to the longest proper suffix state
+	out  []int32        // Lengths (in runes) of the terms recognized in this state
+}
+
+// ahoCorasick matches many literal terms in a single pass over the text.
+//
+// Terms are matched case-insensitively by lower-casing one rune at a time,
+// which keeps byte offsets in the original text exact. Building the
+// automaton is linear in the total size of the terms and each search is
+// linear in the length of the text plus the number of matches, regardless
+// of how many terms are loaded.
+type ahoCorasick struct {
+	nodes []acNode
+}
+
+// newAhoCorasick builds an automaton recognizing every term.
+func newAhoCorasick(terms []string) *ahoCorasick {
+	ac := &ahoCorasick{nodes: []acNode{{next: make(map[rune]int32)}}}
+
+	// Build the trie of lower-cased terms
+	for _, term := range terms {
+		state := int32(0)
+		length := int32(0)
+		for _, r := range term {
+			r = unicode.ToLower(r)
+			next, ok := ac.nodes[state].next[r]
+			if !ok {
+				next = int32(len(ac.nodes))
+				ac.nodes = append(ac.nodes, acNode{next: make(map[rune]int32)})
+				ac.nodes[state].next[r] = next
+			}
+			state = next
+			length++
+		}
+		if length > 0 {
+			ac.nodes[state].out = append(ac.nodes[state].out, length)
+		}
+	}
+
+	// Compute failure links breadth-first, merging the outputs of each
+	// state's failure target so every suffix match is reported
+	queue := make([]int32, 0, len(ac.nodes))
+	for _, child := range ac.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for r, child := range ac.nodes[state].next {
+			fail := ac.nodes[state].fail
+			for fail != 0 {
+				if _, ok := ac.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = ac.nodes[fail].fail
+			}
+			if target, ok := ac.nodes[fail].next[r]; ok && target != child {
+				ac.nodes[child].fail = target
+			}
+			ac.nodes[child].out = append(ac.nodes[child].out, ac.nodes[ac.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+
+	return ac
+}
+
+// findAll returns the byte offsets of every (possibly overlapping)
+// occurrence of any term in text.
+func (ac *ahoCorasick) findAll(text string) [][]int {
+	var matches [][]int
+	var starts []int // Byte offset of each rune seen so far
+	state := int32(0)
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		starts = append(starts, i)
+		r = unicode.ToLower(r)
+
+		// Follow failure links until a transition exists or we reach the root
+		for state != 0 {
+			if _, ok := ac.nodes[state].next[r]; ok {
+				break
+			}
+			state = ac.nodes[state].fail
+		}
+		if next, ok := ac.nodes[state].next[r]; ok {
+			state = next
+		}
+
+		i += size
+		for _, length := range ac.nodes[state].out {
+			matches = append(matches, []int{starts[len(starts)-int(length)], i})
+		}
+	}
+
+	return matches
+}
+
+// selectLeftmostLongest reduces overlapping matches to a non-overlapping
+// set, preferring the earliest start and then the longest match.
+func selectLeftmostLongest(matches [][]int) [][]int {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i][0] != matches[j][0] {
+			return matches[i][0] < matches[j][0]
+		}
+		return matches[i][1] > matches[j][1]
+	})
+
+	var selected [][]int
+	lastEnd := -1
+	for _, m := range matches {
+		if m[0] >= lastEnd {
+			selected = append(selected, m)
+			lastEnd = m[1]
+		}
+	}
+	return selected
+}
@@ -0,0 +1,97 @@
+package piiredact
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestAhoCorasick_FindAll tests overlapping, case-insensitive and multi-byte matches
+func TestAhoCorasick_FindAll(t *testing.T) {
+	ac := newAhoCorasick([]string{"he", "she", "hers", "JOSÉ"})
+
+	tests := []struct {
+		text     string
+		expected [][]int
+	}{
+		{"ushers", [][]int{{1, 4}, {2, 4}, {2, 6}}},
+		{"SHE", [][]int{{0, 3}, {1, 3}}},
+		{"hi josé!", [][]int{{3, 8}}},
+		{"nothing", nil},
+	}
+
+	for _, tt := range tests {
+		got := ac.findAll(tt.text)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("findAll(%q):\nExpected: %v\nGot: %v", tt.text, tt.expected, got)
+		}
+	}
+
+	selected := selectLeftmostLongest(ac.findAll("ushers"))
+	if !reflect.DeepEqual(selected, [][]int{{1, 4}}) {
+		t.Errorf("Expected leftmost-longest [[1 4]], got %v", selected)
+	}
+}
+
+// TestRedactionEngine_LargeDenylist tests denylist redaction through the automaton
+func TestRedactionEngine_LargeDenylist(t *testing.T) {
+	terms := make([]string, acMinTerms)
+	for i := range terms {
+		terms[i] = fmt.Sprintf("project-%d", i)
+	}
+	terms = append(terms, "Project Falcon", "Falcon")
+
+	config := DefaultConfig()
+	config.Denylist = terms
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Status of project falcon and PROJECT-42?"},
+		{UUID: "id2", Speaker: "B", Text: "Falconry and project-420 are fine"},
+	}
+	expected := []string{
+		"Status of [DENYLIST] and [DENYLIST]?",
+		"Falconry and project-420 are fine",
+	}
+
+	result, _ := engine.Process(chunks)
+	for i, chunk := range result {
+		if chunk.Text != expected[i] {
+			t.Errorf("Denylist failed:\nExpected: %s\nGot: %s", expected[i], chunk.Text)
+		}
+	}
+}
+
+// benchmarkLiteralTerms builds a dictionary and a chunk that mentions its last term
+func benchmarkLiteralTerms(n int) ([]string, string) {
+	terms := make([]string, n)
+	for i := range terms {
+		terms[i] = fmt.Sprintf("customer%dsurname", i)
+	}
+	text := "Please transfer me to Customer" + fmt.Sprint(n-1) + "Surname, my callback number is 555-123-4567, thanks"
+	return terms, text
+}
+
+// BenchmarkLiteralMatch_AhoCorasick measures the automaton on 5000 terms
+func BenchmarkLiteralMatch_AhoCorasick(b *testing.B) {
+	terms, text := benchmarkLiteralTerms(5000)
+	p, _ := newDictionaryPattern("NAME", terms)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.findAll(text)
+	}
+}
+
+// BenchmarkLiteralMatch_Alternation measures the naive regex alternation on 5000 terms
+func BenchmarkLiteralMatch_Alternation(b *testing.B) {
+	terms, text := benchmarkLiteralTerms(5000)
+	re := alternationRegex(terms)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		re.FindAllStringIndex(text, -1)
+	}
+}
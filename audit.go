@@ -0,0 +1,71 @@
+package piiredact
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditRecord is the JSON document written to Config.AuditWriter for
+// every redaction.
+//
+// Records are written one per line (JSON Lines). The original value is
+// never included; Masked shows its shape with all but the last four
+// letters or digits replaced by 'X' (values shorter than eight are fully
+// masked). Offset and Length are byte positions in the chunk's original,
+// unredacted text.
+//
+//	{"timestamp":"2025-01-02T15:04:05.123456789Z","chunk_uuid":"id1","pattern":"SSN","offset":10,"length":11,"masked":"XXX-XX-6789"}
+type AuditRecord struct {
+	Timestamp time.Time `json:"timestamp"`  // When the redaction was made (RFC 3339, UTC)
+	ChunkUUID string    `json:"chunk_uuid"` // UUID of the chunk that contained the value
+	Pattern   string    `json:"pattern"`    // Name of the pattern that matched
+	Offset    int       `json:"offset"`     // Byte offset of the value in the original text
+	Length    int       `json:"length"`     // Byte length of the original value
+	Masked    string    `json:"masked"`     // Masked rendering of the value
+}
+
+// audit writes one record per redaction to the configured AuditWriter.
+//
+// Chunks are redacted on several goroutines, so writes are serialized under
+// auditMu and each chunk's records go out in a single Write call, keeping
+// lines from interleaving. The first write error is kept and returned by the
+// next Process call.
+func (e *RedactionEngine) audit(c Chunk, matches []match) {
+	if e.config.AuditWriter == nil || len(matches) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	var buf []byte
+	for _, m := range matches {
+		record := AuditRecord{
+			Timestamp: now,
+			ChunkUUID: c.UUID,
+			Pattern:   e.matchName(m),
+			Offset:    m.start,
+			Length:    m.end - m.start,
+			Masked:    maskValue(c.Text[m.start:m.end]),
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			continue // AuditRecord always marshals; kept for safety
+		}
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+	}
+
+	e.auditMu.Lock()
+	defer e.auditMu.Unlock()
+	if _, err := e.config.AuditWriter.Write(buf); err != nil && e.auditErr == nil {
+		e.auditErr = err
+	}
+}
+
+// takeAuditError returns and clears the first audit write error, if any.
+func (e *RedactionEngine) takeAuditError() error {
+	e.auditMu.Lock()
+	defer e.auditMu.Unlock()
+	err := e.auditErr
+	e.auditErr = nil
+	return err
+}
@@ -0,0 +1,128 @@
+package piiredact
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_AuditWriter tests that one masked record is written per redaction
+func TestRedactionEngine_AuditWriter(t *testing.T) {
+	var out bytes.Buffer
+	config := DefaultConfig()
+	config.AuditWriter = &out
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN: 123-45-6789, Phone: 555-123-4567"},
+		{UUID: "id2", Speaker: "B", Text: "No PII here"},
+	}
+	if _, err := engine.Process(chunks); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("Invalid audit record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %d", len(records))
+	}
+
+	expected := []AuditRecord{
+		{ChunkUUID: "id1", Pattern: "SSN", Offset: 5, Length: 11, Masked: "XXX-XX-6789"},
+		{ChunkUUID: "id1", Pattern: "PHONE", Offset: 25, Length: 12, Masked: "XXX-XXX-4567"},
+	}
+	for i, r := range records {
+		if r.ChunkUUID != expected[i].ChunkUUID || r.Pattern != expected[i].Pattern ||
+			r.Offset != expected[i].Offset || r.Length != expected[i].Length ||
+			r.Masked != expected[i].Masked || r.Timestamp.IsZero() {
+			t.Errorf("Record %d mismatch:\nExpected: %+v\nGot: %+v", i, expected[i], r)
+		}
+	}
+
+	if strings.Contains(out.String(), "6789-") || strings.Contains(out.String(), "123-45") {
+		t.Errorf("Audit trail leaked raw PII: %s", out.String())
+	}
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestRedactionEngine_AuditWriterError tests that audit write failures are reported
+func TestRedactionEngine_AuditWriterError(t *testing.T) {
+	config := DefaultConfig()
+	config.AuditWriter = failingWriter{}
+	engine := NewRedactionEngine(config)
+
+	result, err := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789"}})
+	if err == nil {
+		t.Fatal("Expected an error from a failing audit writer")
+	}
+	if result[0].Text != "My SSN is [SSN]" {
+		t.Errorf("Expected redacted chunk despite audit error, got %q", result[0].Text)
+	}
+}
+
+// TestRedactionEngine_Close tests that buffered audit records are flushed on Close
+func TestRedactionEngine_Close(t *testing.T) {
+	var out bytes.Buffer
+	config := DefaultConfig()
+	config.AuditWriter = bufio.NewWriter(&out)
+	engine := NewRedactionEngine(config)
+
+	if _, err := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789"}}); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+
+	// Records written by a stream are flushed too, once the stream is done
+	for range engine.ProcessStream(context.Background(), chunkChannel("Call 555-123-4567")) {
+	}
+
+	if out.Len() != 0 {
+		t.Fatalf("Expected records to be buffered before Close, got %q", out.String())
+	}
+	if err := engine.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Errorf("Expected 2 audit records after Close, got %d: %q", lines, out.String())
+	}
+	if err := engine.Close(); err != nil {
+		t.Errorf("Second Close returned error: %v", err)
+	}
+
+	// Unreported write errors are returned by Close
+	config.AuditWriter = failingWriter{}
+	engine = NewRedactionEngine(config)
+	for range engine.ProcessStream(context.Background(), chunkChannel("My SSN is 123-45-6789")) {
+	}
+	if err := engine.Close(); err == nil {
+		t.Error("Expected Close to report the audit write error")
+	}
+}
+
+// chunkChannel returns a closed channel holding one chunk per text
+func chunkChannel(texts ...string) <-chan Chunk {
+	in := make(chan Chunk, len(texts))
+	for i, text := range texts {
+		in <- Chunk{UUID: fmt.Sprintf("id%d", i+1), Speaker: "A", Text: text}
+	}
+	close(in)
+	return in
+}
@@ -0,0 +1,62 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// bankWindow is how many bytes after a routing number are searched for the
+// account number that goes with it.
+const bankWindow = 40
+
+// bankPriority lets BANK_INFO win over ABA, PHONE and other patterns that
+// match the same digits.
+const bankPriority = 1
+
+// accountRegex matches account-like digit runs.
+var accountRegex = regexp.MustCompile(`\b[0-9]{4,17}\b`)
+
+// accountCues and accountConnectors introduce an account number, as in
+// "acct no. 00012345" or "account: 1234567890".
+var (
+	accountCues       = []string{"account", "acct", "acc", "a/c"}
+	accountConnectors = []string{"number:", "number", "no.:", "no.", "no:", "no", "#:", "#", ":", "is"}
+)
+
+// accountSeparators may stand alone between a routing number and its
+// account number, as in "021000021 / 1234567890".
+const accountSeparators = " \t,;:/|-"
+
+// abaCandidateRegex matches candidate routing numbers.
+var abaCandidateRegex = regexp.MustCompile(`\b[0-9]{9}\b`)
+
+// findBankInfo finds a valid ABA routing number followed within bankWindow
+// bytes by an account-like number, as in "routing 021000021 account
+// 1234567890", and returns both spans. An account number on its own is too
+// generic to redact, but next to a routing number it clearly is one.
+//
+// The number must follow an account cue, or only separators may come
+// between the two, so a phone number or SSN mentioned after a routing
+// number, as in "routing 021000021, call 404-555-1212", is left to its
+// own pattern.
+func findBankInfo(text string) [][]int {
+	var spans [][]int
+	for _, aba := range abaCandidateRegex.FindAllStringIndex(text, -1) {
+		if !validateABA(text[aba[0]:aba[1]]) {
+			continue
+		}
+
+		// The account number must start inside the window
+		account := accountRegex.FindStringIndex(text[aba[1]:])
+		if account == nil || account[0] >= bankWindow {
+			continue
+		}
+		start, end := aba[1]+account[0], aba[1]+account[1]
+		_, _, cued := cueBefore(text, start, accountCues, accountConnectors)
+		if !cued && strings.Trim(text[aba[1]:start], accountSeparators) != "" {
+			continue
+		}
+		spans = append(spans, aba, []int{start, end})
+	}
+	return spans
+}
@@ -0,0 +1,39 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_BankInfo tests routing and account number pairs
+func TestRedactionEngine_BankInfo(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"routing 021000021 account 1234567890", "routing [BANK_INFO] account [BANK_INFO]"},
+		{"ABA 111000025, acct no. 00012345", "ABA [BANK_INFO], acct no. [BANK_INFO]"},
+		// A routing number on its own stays ABA
+		{"routing 021000021 please", "routing [ABA] please"},
+		// The account number must follow within the window
+		{"routing 021000021 and then a very long digression before 1234567890", "routing [ABA] and then a very long digression before [PHONE]"},
+		// An invalid routing number does not pull in the next number (DL still
+		// claims any 9 digits)
+		{"routing 021000022 account 12345678", "routing [DL] account 12345678"},
+		{"routing 000000000 account 12345678", "routing [DL] account 12345678"},
+		// The account number needs a cue or only separators before it
+		{"021000021 / 1234567890", "[BANK_INFO] / [BANK_INFO]"},
+		{"routing 021000021 acct #: 00012345", "routing [BANK_INFO] acct #: [BANK_INFO]"},
+		{"routing 021000021, call 404-555-1212", "routing [ABA], call [PHONE]"},
+		{"routing 021000021 SSN 123-45-6789", "routing [ABA] SSN [SSN]"},
+		{"routing 021000021 order 12345678", "routing [ABA] order 12345678"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package piiredact
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Base64-encoded PII.
+//
+// With Config.Base64Aware the engine looks for runs of base64 (standard or
+// URL-safe alphabet, padded or not) of at least base64MinLen characters,
+// decodes them and scans the decoded text with every active pattern. A blob
+// whose contents contain PII is redacted as a whole under "BASE64", since
+// redacting part of an encoding is meaningless.
+//
+// Limitations:
+//   - Only blobs that decode to valid UTF-8 are scanned, so compressed or
+//     encrypted payloads are never inspected.
+//   - Blobs longer than Config.Base64MaxDecode are left alone rather than
+//     decoded, which bounds the work a hostile input can cause.
+//   - Line-wrapped (MIME) base64 is seen as one blob per line, and PII split
+//     across lines may be missed.
+//   - Base64 shorter than base64MinLen, which covers values of fewer than
+//     9 bytes, is not decoded.
+
+// base64MinLen is the shortest run of base64 characters that is decoded;
+// it is long enough for the encoding of an unformatted SSN (9 bytes).
+const base64MinLen = 12
+
+// defaultBase64MaxDecode is used when Config.Base64MaxDecode is not set.
+const defaultBase64MaxDecode = 64 << 10
+
+// base64Priority lets a redacted blob win over patterns matching its text.
+const base64Priority = urlPriority - 1
+
+// base64Regex matches runs of the standard or the URL-safe alphabet.
+var base64Regex = regexp.MustCompile(
+	`[A-Za-z0-9+/]{` + strconv.Itoa(base64MinLen) + `,}={0,2}|[A-Za-z0-9_-]{` + strconv.Itoa(base64MinLen) + `,}={0,2}`)
+
+// base64Encodings are tried in turn when decoding a candidate blob.
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding, base64.RawStdEncoding,
+	base64.URLEncoding, base64.RawURLEncoding,
+}
+
+// base64Pattern returns the pattern behind Config.Base64Aware.
+func (e *RedactionEngine) base64Pattern() PatternDef {
+	maxDecode := e.config.Base64MaxDecode
+	if maxDecode <= 0 {
+		maxDecode = defaultBase64MaxDecode
+	}
+
+	return PatternDef{
+		Name:        "BASE64",
+		Description: "Base64-encoded data containing PII",
+		Regex:       base64Regex,
+		Priority:    base64Priority,
+		find: func(text string) [][]int {
+			var spans [][]int
+			for _, m := range base64Regex.FindAllStringIndex(text, -1) {
+				if m[1]-m[0] > maxDecode {
+					continue
+				}
+				decoded, ok := decodeBase64(text[m[0]:m[1]])
+				if !ok {
+					continue
+				}
+				if matches, _ := e.findMatches(decoded, nil); len(matches) > 0 {
+					spans = append(spans, m)
+				}
+			}
+			return spans
+		},
+	}
+}
+
+// decodeBase64 decodes blob with the first encoding that accepts it and
+// reports whether the result is UTF-8 text.
+func decodeBase64(blob string) (string, bool) {
+	for _, enc := range base64Encodings {
+		decoded, err := enc.DecodeString(blob)
+		if err == nil {
+			return string(decoded), utf8.Valid(decoded)
+		}
+	}
+	return "", false
+}
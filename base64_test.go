@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_Base64Aware tests redaction of base64 blobs containing PII
+func TestRedactionEngine_Base64Aware(t *testing.T) {
+	config := DefaultConfig()
+	config.Base64Aware = true
+	engine := NewRedactionEngine(config)
+
+	ssn := base64.StdEncoding.EncodeToString([]byte("123-45-6789"))
+	email := base64.RawURLEncoding.EncodeToString([]byte(`{"email":"jane@example.com"}`))
+	clean := base64.StdEncoding.EncodeToString([]byte("nothing sensitive here"))
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"payload=" + ssn, "payload=[BASE64]"},
+		{"token " + email + " end", "token [BASE64] end"},
+		{"blob " + clean, "blob " + clean},
+		{"card 4111111111111111", "card [CC]"},
+		{"plain 123-45-6789", "plain [SSN]"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Off by default
+	plain := NewRedactionEngine(DefaultConfig())
+	result, _ := plain.Process([]Chunk{{UUID: "u", Speaker: "A", Text: ssn}})
+	if result[0].Text != ssn {
+		t.Errorf("Base64 redacted without Base64Aware: %s", result[0].Text)
+	}
+}
+
+// TestRedactionEngine_Base64MaxDecode tests that oversized blobs are not decoded
+func TestRedactionEngine_Base64MaxDecode(t *testing.T) {
+	config := DefaultConfig()
+	config.Base64Aware = true
+	config.Base64MaxDecode = 32
+	engine := NewRedactionEngine(config)
+
+	small := base64.StdEncoding.EncodeToString([]byte("SSN 123-45-6789"))
+	large := base64.StdEncoding.EncodeToString([]byte("SSN 123-45-6789" + strings.Repeat(" ", 30)))
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: small + " " + large}})
+	if expected := "[BASE64] " + large; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
@@ -0,0 +1,242 @@
+package piiredact
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// realisticChunk is a call-centre transcript turn of about 1 KB with a
+// typical density of PII among ordinary speech.
+var realisticChunk = strings.Join([]string{
+	"Thank you for calling, my name is Alex and I'll be helping you today.",
+	"Before we get started, can I get the account holder's full name and date of birth?",
+	"Sure, it's under my name and my date of birth is 04/15/1985.",
+	"Great, and for verification can you read me the last four, actually the full social, 401-23-4567?",
+	"Okay, I see the account. The card on file ends in 1111, the full number is 4111 1111 1111 1111.",
+	"Is the best callback number still (404) 555-1212, or would you like to use 404-555-3434 ext. 12?",
+	"You can also email the documents to jane.doe@example.com and we'll attach them to the case.",
+	"For the direct deposit we'll need the routing number, 111000025, and your account number.",
+	"I also see a login from 192.168.10.25 yesterday, was that you? Alright, nothing else looks unusual.",
+	"Is there anything else I can help you with today? Great, thanks for your patience, have a good one.",
+}, " ")
+
+// plainChunk is a call-centre transcript turn of about 1 KB with no PII,
+// no digits and no '@', as most turns of a conversation are.
+var plainChunk = strings.Join([]string{
+	"Thank you for calling, my name is Alex and I'll be helping you today.",
+	"I understand the delivery was late again, and I'm sorry about that.",
+	"Let me take a look at what happened with the courier on our side.",
+	"It looks like the package was held at the sorting center over the weekend.",
+	"I can request that they prioritize it, and I'll add a note to your account.",
+	"Is there anything else that you would like me to check while we're on the line?",
+	"Alright, I've also sent a confirmation so you have a record of this conversation.",
+	"If it doesn't arrive by the end of the week, just reply and we'll escalate it.",
+	"Thanks again for your patience, and I hope the rest of your day goes well.",
+	"Take care, goodbye.",
+}, " ")
+
+// benchmarkChunks builds n chunks that each contain a mix of PII and plain text
+func benchmarkChunks(n int) []Chunk {
+	chunks := make([]Chunk, n)
+	for i := range chunks {
+		chunks[i] = Chunk{
+			UUID:    fmt.Sprintf("id%d", i),
+			Speaker: "A",
+			Text:    "SSN: 123-45-6789, Phone: 555-123-4567, Email: user@example.com",
+		}
+	}
+	return chunks
+}
+
+// processChunksPerGoroutine is the previous semaphore-based implementation,
+// kept here so the worker pool can be benchmarked against it.
+func (e *RedactionEngine) processChunksPerGoroutine(chunks []Chunk) []Chunk {
+	result := make([]Chunk, len(chunks))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, e.config.MaxConcurrency)
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		semaphore <- struct{}{} // Acquire semaphore
+
+		go func(i int, c Chunk) {
+			defer wg.Done()
+			defer func() { <-semaphore }() // Release semaphore
+			result[i] = e.redactChunk(c)
+		}(i, chunk)
+	}
+
+	wg.Wait()
+	return result
+}
+
+// BenchmarkProcessChunks_WorkerPool measures the fixed worker pool on 100k chunks
+func BenchmarkProcessChunks_WorkerPool(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunks := benchmarkChunks(100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.processChunks(chunks, nil)
+	}
+}
+
+// BenchmarkProcessChunks_PerGoroutine measures one goroutine per chunk on 100k chunks
+func BenchmarkProcessChunks_PerGoroutine(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunks := benchmarkChunks(100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.processChunksPerGoroutine(chunks)
+	}
+}
+
+// BenchmarkRedactChunk measures redacting one realistic chunk
+func BenchmarkRedactChunk(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunk := Chunk{UUID: "id", Speaker: "A", Text: realisticChunk}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(chunk.Text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.redactChunk(chunk)
+	}
+}
+
+// BenchmarkProcess measures Process over batch sizes and concurrency levels
+func BenchmarkProcess(b *testing.B) {
+	for _, n := range []int{1, 100, 10000} {
+		for _, workers := range []int{1, 4, 16} {
+			b.Run(fmt.Sprintf("chunks=%d/workers=%d", n, workers), func(b *testing.B) {
+				config := DefaultConfig()
+				config.MaxConcurrency = workers
+				engine := NewRedactionEngine(config)
+
+				chunks := make([]Chunk, n)
+				for i := range chunks {
+					chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: realisticChunk}
+				}
+
+				b.ReportAllocs()
+				b.SetBytes(int64(n * len(realisticChunk)))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					engine.Process(chunks)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkValidateLuhn measures the Luhn check on a formatted card number
+func BenchmarkValidateLuhn(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validateLuhn("4111 1111 1111 1111")
+	}
+}
+
+// manyPhonesChunk returns a chunk text containing n phone numbers
+func manyPhonesChunk(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "line %d: call 404-555-%04d today. ", i, i)
+	}
+	return b.String()
+}
+
+// BenchmarkRedactChunk_ManyMatches measures a chunk with 500 phone numbers,
+// where per-match string rebuilding would be quadratic
+func BenchmarkRedactChunk_ManyMatches(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunk := Chunk{UUID: "id", Speaker: "A", Text: manyPhonesChunk(500)}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(chunk.Text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.redactChunk(chunk)
+	}
+}
+
+// BenchmarkRedactChunk_Plain measures a chunk without PII, where patterns
+// whose required characters are absent skip their regexes
+func BenchmarkRedactChunk_Plain(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunk := Chunk{UUID: "id", Speaker: "A", Text: plainChunk}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(chunk.Text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.redactChunk(chunk)
+	}
+}
+
+// BenchmarkProcess_MostlyPlain measures a batch where 95% of chunks have
+// no PII, with and without ShareUnchanged
+func BenchmarkProcess_MostlyPlain(b *testing.B) {
+	for _, n := range []int{20, 10000} {
+		chunks := make([]Chunk, n)
+		for i := range chunks {
+			chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: plainChunk}
+			if i%20 == 19 {
+				chunks[i].Text = realisticChunk
+			}
+		}
+
+		for _, share := range []bool{false, true} {
+			b.Run(fmt.Sprintf("chunks=%d/share=%v", n, share), func(b *testing.B) {
+				config := DefaultConfig()
+				config.ShareUnchanged = share
+				engine := NewRedactionEngine(config)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					engine.Process(chunks)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkRedactChunk_Mixed measures a mix of PII-heavy and PII-free
+// chunks, reporting how many patterns' regexes run per chunk once the
+// engine's precomputed required characters rule the others out
+func BenchmarkRedactChunk_Mixed(b *testing.B) {
+	engine := NewRedactionEngine(DefaultConfig())
+	chunks := make([]Chunk, 20)
+	for i := range chunks {
+		chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: plainChunk}
+		if i%4 == 0 {
+			chunks[i].Text = realisticChunk
+		}
+	}
+
+	runs := 0
+	for _, c := range chunks {
+		present := engine.requires.present(c.Text)
+		for i := range engine.patterns {
+			if engine.requires.met(i, c.Text, present) {
+				runs++
+			}
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range chunks {
+			engine.redactChunk(c)
+		}
+	}
+	b.ReportMetric(float64(runs)/float64(len(chunks)), "regexes/chunk")
+	b.ReportMetric(float64(len(engine.patterns)), "patterns")
+}
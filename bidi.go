@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bidi formatting characters that open a directional run and must be
+// closed by PDF (embeddings and overrides) or PDI (isolates).
+const (
+	lri = '\u2066' // Left-to-right isolate
+	pdi = '\u2069' // Pop directional isolate
+)
+
+// bidiPaired lists the embedding, override and isolate characters and
+// their terminators, LRE through RLO and LRI through PDI.
+const bidiPaired = "\u202a\u202b\u202c\u202d\u202e\u2066\u2067\u2068\u2069"
+
+// rtlScripts are the scripts written right to left.
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+}
+
+// hasRTL reports whether text contains a letter of a right-to-left script.
+func hasRTL(text string) bool {
+	for _, r := range text {
+		if r >= 0x0590 && unicode.In(r, rtlScripts...) {
+			return true
+		}
+	}
+	return false
+}
+
+// bidiSafe adjusts the replacement for value so it cannot break the
+// rendering of mixed-direction text.
+//
+// Any embedding, override or isolate characters inside value are kept,
+// in order, after the replacement: dropping an opener or its terminator
+// would leave the rest of the line in the wrong direction. With isolate
+// set, the replacement is also wrapped in LRI and PDI, so a Latin label
+// such as "[PHONE]" keeps its brackets and reading order inside Arabic or
+// Hebrew text.
+func bidiSafe(value, replacement string, isolate bool) string {
+	if isolate {
+		replacement = string(lri) + replacement + string(pdi)
+	}
+	if !strings.ContainsAny(value, bidiPaired) {
+		return replacement
+	}
+
+	var b strings.Builder
+	b.WriteString(replacement)
+	for _, r := range value {
+		if strings.ContainsRune(bidiPaired, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
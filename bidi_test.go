@@ -0,0 +1,72 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// bidiBalanced reports whether every embedding, override and isolate in s
+// is closed by the matching terminator.
+func bidiBalanced(s string) bool {
+	var stack []rune
+	for _, r := range s {
+		switch r {
+		case '\u202a', '\u202b', '\u202d', '\u202e':
+			stack = append(stack, '\u202c')
+		case '\u2066', '\u2067', '\u2068':
+			stack = append(stack, '\u2069')
+		case '\u202c', '\u2069':
+			if len(stack) == 0 || stack[len(stack)-1] != r {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return len(stack) == 0
+}
+
+// TestRedactionEngine_Bidi tests redaction of Latin PII in right-to-left text
+func TestRedactionEngine_Bidi(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{Name: "ID", Regex: regexp.MustCompile(`ID-\S+`)},
+	}
+	plain := NewRedactionEngine(config)
+	config.BidiIsolateLabels = true
+	isolated := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		plain    string
+		isolated string
+	}{
+		// Hebrew and Arabic with embedded Latin PII
+		{"מספר הטלפון שלי 555-123-4567 תודה", "מספר הטלפון שלי [PHONE] תודה", "מספר הטלפון שלי \u2066[PHONE]\u2069 תודה"},
+		{"بريدي jane@example.com شكرا", "بريدي [EMAIL] شكرا", "بريدي \u2066[EMAIL]\u2069 شكرا"},
+		// Latin-only text is never wrapped
+		{"call 555-123-4567", "call [PHONE]", "call [PHONE]"},
+		// Isolates inside a match are kept so the line stays balanced
+		{"ID-\u2067שלום\u2069 end", "[ID]\u2067\u2069 end", "\u2066[ID]\u2069\u2067\u2069 end"},
+		{"ID-\u2067abc שלום\u2069", "[ID]\u2067 שלום\u2069", "\u2066[ID]\u2069\u2067 שלום\u2069"},
+	}
+
+	for _, tc := range testCases {
+		for _, run := range []struct {
+			engine   *RedactionEngine
+			expected string
+		}{{plain, tc.plain}, {isolated, tc.isolated}} {
+			result, _ := run.engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+			got := result[0].Text
+			if got != run.expected {
+				t.Errorf("Input: %q\nExpected: %q\nGot: %q", tc.input, run.expected, got)
+			}
+			if bidiBalanced(tc.input) && !bidiBalanced(got) {
+				t.Errorf("Output %q has unbalanced bidi controls", got)
+			}
+			if strings.Contains(got, "555-123-4567") || strings.Contains(got, "jane@") {
+				t.Errorf("Output %q still contains PII", got)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package piiredact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Blind indexes.
+//
+// A blind index term is a keyed hash of a redacted value that can be
+// stored next to the redacted record, so that records mentioning a value
+// can be found later without the value itself being stored. To look a
+// value up, compute its term with BlindIndexTerm and search for it.
+//
+// Terms are HMAC-SHA256 under Config.BlindIndexKey of the pattern name and
+// the value, written as 64 hex characters. They are deterministic per key:
+// the same value under the same pattern always gets the same term, in any
+// engine and any process with that key, and a different key gives
+// unrelated terms. Anyone holding the key can confirm a guessed value, so
+// it must be kept as secret as the data and should not be the TokenKey.
+// Terms are derived separately from tokens, so a term never reveals the
+// value's token or the other way round, even under the same key.
+//
+// Values are compared ignoring case and everything but letters and
+// digits, so "123-45-6789" and "123 45 6789" share a term, as do
+// "Jane@Example.com" and "jane@example.com". Terms are not scoped by
+// speaker, whatever Config.TokenScope says.
+
+// ErrNoBlindIndexKey is returned by ProcessWithBlindIndex and
+// BlindIndexTerm when Config.BlindIndexKey is not set.
+var ErrNoBlindIndexKey = errors.New("piiredact: blind index key is not set")
+
+// blindIndexDomain is hashed ahead of every term to keep terms apart from
+// tokens derived with the same key.
+const blindIndexDomain = "piiredact blind index"
+
+// BlindIndex lists the blind index terms of one chunk.
+type BlindIndex struct {
+	UUID  string   `json:"uuid"`  // UUID of the chunk
+	Terms []string `json:"terms"` // One term per distinct redacted value, sorted; empty if nothing was redacted
+}
+
+// ProcessWithBlindIndex is Process that also returns the blind index terms
+// of each chunk's redacted values, in the same order as chunks. Values
+// left in the text, such as those OnMatch rejected, and chunks withheld by
+// FailClosed are not indexed.
+//
+// It returns ErrNoBlindIndexKey, without processing anything, if
+// Config.BlindIndexKey is not set.
+func (e *RedactionEngine) ProcessWithBlindIndex(chunks []Chunk) ([]Chunk, []BlindIndex, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	if len(e.config.BlindIndexKey) == 0 {
+		return nil, nil, ErrNoBlindIndexKey
+	}
+
+	indexes := make([]BlindIndex, len(chunks))
+	for i, c := range chunks {
+		indexes[i] = BlindIndex{UUID: c.UUID, Terms: []string{}}
+	}
+	result, err := e.process(chunks, func(i int, r *redaction) {
+		var terms []string
+		for _, m := range r.matches {
+			value := r.text[m.start:m.end]
+			if m.name == failClosedLabel || m.replacement == value {
+				continue
+			}
+			terms = append(terms, e.blindIndexTerm(e.matchName(m), value))
+		}
+		if len(terms) > 0 {
+			slices.Sort(terms)
+			indexes[i].Terms = slices.Compact(terms)
+		}
+	})
+	return result, indexes, err
+}
+
+// BlindIndexTerm returns the blind index term of value as a match of the
+// named pattern, as ProcessWithBlindIndex would record it. Use it to find
+// the records that mention a value. It returns ErrNoBlindIndexKey if
+// Config.BlindIndexKey is not set.
+func (e *RedactionEngine) BlindIndexTerm(patternName, value string) (string, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	if len(e.config.BlindIndexKey) == 0 {
+		return "", ErrNoBlindIndexKey
+	}
+	return e.blindIndexTerm(patternName, value), nil
+}
+
+// blindIndexTerm derives the term of a value under Config.BlindIndexKey.
+func (e *RedactionEngine) blindIndexTerm(name, value string) string {
+	mac := hmac.New(sha256.New, e.config.BlindIndexKey)
+	mac.Write([]byte(blindIndexDomain))
+	mac.Write([]byte{0})
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write([]byte(blindIndexValue(value)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// blindIndexValue reduces value to its lowercased letters and digits.
+func blindIndexValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, value)
+}
@@ -0,0 +1,87 @@
+package piiredact
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestRedactionEngine_BlindIndex tests finding redacted records by the term of a query value
+func TestRedactionEngine_BlindIndex(t *testing.T) {
+	config := DefaultConfig()
+	config.BlindIndexKey = []byte("index-key")
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Text: "SSN 123-45-6789, mail jane@example.com"},
+		{UUID: "id2", Text: "nothing to see"},
+		{UUID: "id3", Text: "again 123 45 6789 and 123-45-6789"},
+	}
+	result, indexes, err := engine.ProcessWithBlindIndex(chunks)
+	if err != nil {
+		t.Fatalf("ProcessWithBlindIndex failed: %v", err)
+	}
+	if expected := "SSN [SSN], mail [EMAIL]"; result[0].Text != expected {
+		t.Errorf("Expected %q, got %q", expected, result[0].Text)
+	}
+	if len(indexes) != len(chunks) {
+		t.Fatalf("Expected %d indexes, got %d", len(chunks), len(indexes))
+	}
+	if indexes[0].UUID != "id1" || len(indexes[0].Terms) != 2 || len(indexes[1].Terms) != 0 || len(indexes[2].Terms) != 1 {
+		t.Errorf("Unexpected indexes: %+v", indexes)
+	}
+
+	// A query term computed by another engine with the same key finds both SSN records
+	query, err := NewRedactionEngine(config).BlindIndexTerm("SSN", "123456789")
+	if err != nil {
+		t.Fatalf("BlindIndexTerm failed: %v", err)
+	}
+	var found []string
+	for _, index := range indexes {
+		if slices.Contains(index.Terms, query) {
+			found = append(found, index.UUID)
+		}
+	}
+	if !slices.Equal(found, []string{"id1", "id3"}) {
+		t.Errorf("Expected the SSN in id1 and id3, found it in %v", found)
+	}
+
+	// Case and punctuation do not change the term; the pattern and key do
+	email, _ := engine.BlindIndexTerm("EMAIL", "Jane@Example.com")
+	if !slices.Contains(indexes[0].Terms, email) {
+		t.Errorf("Email term %s not in %v", email, indexes[0].Terms)
+	}
+	if other, _ := engine.BlindIndexTerm("PHONE", "123456789"); other == query {
+		t.Error("Expected terms to depend on the pattern")
+	}
+	config.BlindIndexKey = []byte("other-key")
+	if other, _ := NewRedactionEngine(config).BlindIndexTerm("SSN", "123456789"); other == query {
+		t.Error("Expected terms to depend on the key")
+	}
+}
+
+// TestRedactionEngine_BlindIndexSeparateFromTokens tests that terms do not reveal tokens
+func TestRedactionEngine_BlindIndexSeparateFromTokens(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = ModeToken
+	config.TokenKey = []byte("shared-key")
+	config.BlindIndexKey = config.TokenKey
+	engine := NewRedactionEngine(config)
+
+	term, _ := engine.BlindIndexTerm("SSN", "123456789")
+	token := engine.token("SSN", "", "123456789")
+	if term[:tokenHexLen] == token[len("SSN_"):] {
+		t.Errorf("Blind index term %s matches token %s", term, token)
+	}
+}
+
+// TestRedactionEngine_BlindIndexNoKey tests that blind indexing needs a key
+func TestRedactionEngine_BlindIndexNoKey(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	if _, _, err := engine.ProcessWithBlindIndex([]Chunk{{Text: "SSN 123-45-6789"}}); !errors.Is(err, ErrNoBlindIndexKey) {
+		t.Errorf("Expected ErrNoBlindIndexKey, got %v", err)
+	}
+	if _, err := engine.BlindIndexTerm("SSN", "123-45-6789"); !errors.Is(err, ErrNoBlindIndexKey) {
+		t.Errorf("Expected ErrNoBlindIndexKey, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package piiredact
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Unicode word boundaries.
+//
+// Go's \b only knows ASCII word characters, so builtin patterns treat any
+// other letter as a boundary: "josé.garcía@example.com" is matched from the
+// final "a" and "café123456789" yields an SSN. With Config.UnicodeBoundaries
+// a match must instead start and end on a boundary between a word rune and
+// a non-word rune in any script, and EMAIL accepts non-ASCII local parts.
+//
+// Scripts written without spaces between words (Han, Hiragana, Katakana,
+// Thai, Lao, Khmer, Myanmar) are the exception: their letters always count
+// as a boundary, so "邮箱jane@example.com" is still redacted.
+
+// unsegmentedScripts are scripts whose words are not separated by spaces.
+var unsegmentedScripts = []*unicode.RangeTable{
+	unicode.Han, unicode.Hiragana, unicode.Katakana,
+	unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar,
+}
+
+// unicodeEmailRegex is the EMAIL pattern used with Unicode boundaries. Its
+// local part accepts letters and combining marks of any script that uses
+// spaces, so it stops at adjacent CJK text.
+var unicodeEmailRegex = regexp.MustCompile(
+	`(?:[^\P{L}\p{Han}\p{Hiragana}\p{Katakana}\p{Thai}\p{Lao}\p{Khmer}\p{Myanmar}]|\p{M}|[0-9._%+-])+` +
+		`@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}\b`)
+
+// isSpacedWordRune reports whether r is part of a word in a script that
+// separates words with spaces.
+func isSpacedWordRune(r rune) bool {
+	if !isWordRune(r) && !unicode.IsMark(r) {
+		return false
+	}
+	return !unicode.In(r, unsegmentedScripts...)
+}
+
+// unicodeBoundary is the ContextValidate used by Config.UnicodeBoundaries.
+// It rejects a match whose first or last rune is a word rune glued to
+// another word rune outside it.
+func unicodeBoundary(text string, start, end int) bool {
+	if start > 0 {
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		prev, _ := utf8.DecodeLastRuneInString(text[:start])
+		if isSpacedWordRune(first) && isSpacedWordRune(prev) {
+			return false
+		}
+	}
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if isSpacedWordRune(last) && isSpacedWordRune(next) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package piiredact
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestRedactionEngine_UnicodeBoundaries tests redaction in mixed-script text
+func TestRedactionEngine_UnicodeBoundaries(t *testing.T) {
+	config := DefaultConfig()
+	config.UnicodeBoundaries = true
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		// CJK has no spaces, so adjacent characters are boundaries
+		{"邮箱jane@example.com。", "邮箱[EMAIL]。"},
+		{"メールはjane@example.comです", "メールは[EMAIL]です"},
+		{"电话404-555-1212号", "电话[PHONE]号"},
+		{"社会保障番号123-45-6789です", "社会保障番号[SSN]です"},
+		// Accented local parts are redacted in full
+		{"write to josé.garcía@example.com", "write to [EMAIL]"},
+		{"邮箱josé@example.com", "邮箱[EMAIL]"},
+		// Digits glued to accented words are not identifiers
+		{"café123456789", "café123456789"},
+		{"Straße 123-45-6789", "Straße [SSN]"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_UnicodeBoundariesWithContext tests that Unicode
+// boundaries also apply to patterns with their own context check
+func TestRedactionEngine_UnicodeBoundariesWithContext(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config func(*Config)
+	}{
+		{"UnicodeBoundaries", func(c *Config) { c.UnicodeBoundaries = true }},
+		{"StrictnessStrict", func(c *Config) { c.Strictness = StrictnessStrict }},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.SkipIPVersions = true
+		tc.config(&config)
+		engine := NewRedactionEngine(config)
+
+		for input, expected := range map[string]string{
+			"éé192.168.1.1":            "éé192.168.1.1",
+			"host 192.168.1.1":         "host [IP]",
+			"version 192.168.1.1 here": "version 192.168.1.1 here",
+		} {
+			result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+			if result[0].Text != expected {
+				t.Errorf("%s with SkipIPVersions, input %q\nExpected: %s\nGot: %s", tc.name, input, expected, result[0].Text)
+			}
+		}
+	}
+}
+
+// TestPatternDef_ContextValidate tests that custom patterns can check their surroundings
+func TestPatternDef_ContextValidate(t *testing.T) {
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"EMAIL": true}
+	config.CustomPatterns = []PatternDef{{
+		Name:  "MRN",
+		Regex: regexp.MustCompile(`\b\d{7}\b`),
+		ContextValidate: func(text string, start, end int) bool {
+			return start >= 4 && text[start-4:start] == "MRN "
+		},
+	}}
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "MRN 1234567 and order 7654321"}})
+	if expected := "MRN [MRN] and order 7654321"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
@@ -0,0 +1,87 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Card numbers.
+//
+// By default CC redacts only full 16-digit card numbers, written as one
+// run or in groups of four, that pass the Luhn check or start with one of
+// Config.LuhnExemptPrefixes. Digit runs that fail the check are left alone,
+// since most are order, account or tracking numbers rather than cards.
+//
+// Three options change this. Config.CCSkipLuhn redacts every card-shaped
+// run of 13 to 19 digits, with or without Luhn, for data where mistyped
+// card numbers must not leak. Config.CCFragments redacts the partial
+// references agents read out, such as the "1111" in "the card ends in
+// 1111", as CC_FRAGMENT; a four-digit number is only treated as a fragment
+// directly after a cue such as "ends in" or "last four". Config.CCWrapped
+// accepts a single line break, with any spaces or tabs around it, in place
+// of a separator, so a number soft-wrapped as "4111 1111\n1111 1111" is
+// checked as one and redacted across both lines.
+
+// cardDigitsRegex matches 13 to 19 digits, optionally separated by single
+// spaces or dashes.
+var cardDigitsRegex = regexp.MustCompile(`\b\d(?:[- ]?\d){12,18}\b`)
+
+// wrappedSeparator matches an optional space or dash, then an optional
+// line break with the spaces or tabs around it.
+const wrappedSeparator = `[- ]?(?:[ \t]*\r?\n[ \t]*)?`
+
+// wrappedCardRegex is the CC regex with a line break allowed between
+// groups of four.
+var wrappedCardRegex = regexp.MustCompile(`\b(?:\d{4}` + wrappedSeparator + `\d{4}` + wrappedSeparator + `\d{4}` + wrappedSeparator + `\d{4}|\d{16})\b`)
+
+// wrappedCardDigitsRegex is cardDigitsRegex with a line break allowed
+// between any two digits.
+var wrappedCardDigitsRegex = regexp.MustCompile(`\b\d(?:` + wrappedSeparator + `\d){12,18}\b`)
+
+// lineBreakRemover strips the line break characters a wrapped card match
+// may contain, leaving the separators validateLuhn expects.
+var lineBreakRemover = strings.NewReplacer("\r", "", "\n", "", "\t", "")
+
+// wrappedCardPattern returns the CC pattern with line breaks allowed
+// between digits, as set by Config.CCWrapped. The validator sees the
+// number with the line breaks removed.
+func wrappedCardPattern(p PatternDef) PatternDef {
+	if p.Regex == cardDigitsRegex {
+		p.Regex = wrappedCardDigitsRegex
+	} else {
+		p.Regex = wrappedCardRegex
+	}
+	if validate := p.Validate; validate != nil {
+		p.Validate = func(value string) bool {
+			return validate(lineBreakRemover.Replace(value))
+		}
+	}
+	return p
+}
+
+// cardFragmentCues are the lowercase phrases that introduce the last digits
+// of a card.
+var cardFragmentCues = []string{
+	"ends in", "ends with", "ending in", "ending with", "ending",
+	"last four", "last 4", "last four digits", "last 4 digits",
+}
+
+// cardFragmentConnectors may separate a fragment cue from the digits.
+var cardFragmentConnectors = []string{":", "is", "are", "of"}
+
+// afterCardFragmentCue reports whether the match at start directly follows
+// one of cardFragmentCues.
+func afterCardFragmentCue(text string, start, end int) bool {
+	_, _, ok := cueBefore(text, start, cardFragmentCues, cardFragmentConnectors)
+	return ok
+}
+
+// cardFragmentPattern detects card fragments when Config.CCFragments is set.
+var cardFragmentPattern = PatternDef{
+	Name:            "CC_FRAGMENT",
+	Description:     "Part of a credit card number",
+	Regex:           regexp.MustCompile(`\b\d{4}\b`),
+	ContextValidate: afterCardFragmentCue,
+	Sensitivity:     SensitivityMedium,
+	Requires:        digitChars,
+}
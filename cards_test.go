@@ -0,0 +1,101 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_CardOptions tests Luhn handling and card fragments
+func TestRedactionEngine_CardOptions(t *testing.T) {
+	inputs := []string{
+		"Card 4111 1111 1111 1111 on file",
+		"Card 4111 1111 1111 1112 on file",
+		"Amex 378282246310005 on file",
+		"The card ends in 1111, thanks",
+		"Last four digits: 4242",
+		"Order 1111 shipped",
+	}
+
+	testCases := []struct {
+		name      string
+		skipLuhn  bool
+		fragments bool
+		expected  []string
+	}{
+		{"default", false, false, []string{
+			"Card [CC] on file",
+			"Card 4111 1111 1111 1112 on file",
+			"Amex 378282246310005 on file",
+			"The card ends in 1111, thanks",
+			"Last four digits: 4242",
+			"Order 1111 shipped",
+		}},
+		{"skip luhn", true, false, []string{
+			"Card [CC] on file",
+			"Card [CC] on file",
+			"Amex [CC] on file",
+			"The card ends in 1111, thanks",
+			"Last four digits: 4242",
+			"Order 1111 shipped",
+		}},
+		{"fragments", false, true, []string{
+			"Card [CC] on file",
+			"Card 4111 1111 1111 1112 on file",
+			"Amex 378282246310005 on file",
+			"The card ends in [CC_FRAGMENT], thanks",
+			"Last four digits: [CC_FRAGMENT]",
+			"Order 1111 shipped",
+		}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.CCSkipLuhn = tc.skipLuhn
+		config.CCFragments = tc.fragments
+		engine := NewRedactionEngine(config)
+
+		for i, input := range inputs {
+			result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+			if result[0].Text != tc.expected[i] {
+				t.Errorf("%s: Input: %s\nExpected: %s\nGot: %s", tc.name, input, tc.expected[i], result[0].Text)
+			}
+		}
+	}
+}
+
+// TestRedactionEngine_CCWrapped tests card numbers split across lines
+func TestRedactionEngine_CCWrapped(t *testing.T) {
+	testCases := []struct {
+		skipLuhn bool
+		input    string
+		expected string
+	}{
+		{false, "card 4111 1111\n1111 1111 thanks", "card [CC] thanks"},
+		{false, "card 4111-1111-1111-\n1111", "card [CC]"},
+		{false, "card 4111 1111 \r\n  1111 1111", "card [CC]"},
+		{false, "4111 1111 1111 1111 on one line", "[CC] on one line"},
+		// Wrapped numbers must still pass Luhn, and only one line break is joined
+		{false, "card 4111 1111\n1111 1112", "card 4111 1111\n1111 1112"},
+		{false, "card 4111 1111\n\n1111 1111", "card 4111 1111\n\n1111 1111"},
+		// Without the Luhn check a break may fall anywhere
+		{true, "card 4111 11\n11 1111 1112", "card [CC]"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.CCWrapped = true
+		config.CCSkipLuhn = tc.skipLuhn
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %q\nExpected: %q\nGot: %q", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without the option the halves are left as they are
+	input := "card 4111 1111\n1111 1111"
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if result[0].Text != input {
+		t.Errorf("Expected %q unchanged, got %q", input, result[0].Text)
+	}
+}
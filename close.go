@@ -0,0 +1,55 @@
+package piiredact
+
+import (
+	"errors"
+	"fmt"
+)
+
+// flusher is implemented by buffered writers such as *bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Close waits for in-flight work and flushes the engine's outputs, after
+// which the engine can be discarded without losing audit records or token
+// mappings.
+//
+// It waits for the workers of every ProcessStream call to finish, so each
+// stream's input channel must be closed or its context cancelled first.
+// It then flushes AuditWriter and TokenSidecar if they have a Flush() error
+// method, as *bufio.Writer does; the writers themselves are not closed,
+// since the caller owns them. The returned error joins any flush error with
+// audit and sidecar write errors not yet reported by Process.
+//
+// Process, ProcessStream and the other redaction methods must not be
+// called after Close. Calling Close again returns the first call's result.
+func (e *RedactionEngine) Close() error {
+	e.closeOnce.Do(func() {
+		e.streams.Wait()
+
+		var errs []error
+		if err := e.takeAuditError(); err != nil {
+			errs = append(errs, fmt.Errorf("piiredact: writing audit record: %w", err))
+		}
+		if err := e.sidecar.writeError(); err != nil {
+			errs = append(errs, fmt.Errorf("piiredact: writing token sidecar: %w", err))
+		}
+
+		e.configMu.RLock()
+		defer e.configMu.RUnlock()
+		if f, ok := e.config.AuditWriter.(flusher); ok {
+			e.auditMu.Lock()
+			err := f.Flush()
+			e.auditMu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("piiredact: flushing audit writer: %w", err))
+			}
+		}
+		if err := e.sidecar.flush(); err != nil {
+			errs = append(errs, fmt.Errorf("piiredact: flushing token sidecar: %w", err))
+		}
+
+		e.closeErr = errors.Join(errs...)
+	})
+	return e.closeErr
+}
@@ -9,9 +9,9 @@ import (
 func main() {
 	// Create sample data with PII
 	chunks := []piiredact.Chunk{
-		{"id1", "A", "My SSN is 123-45-6789"},
-		{"id2", "B", "Call me at 555-123-4567"},
-		{"id3", "A", "My email is user@example.com"},
+		{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789"},
+		{UUID: "id2", Speaker: "B", Text: "Call me at 555-123-4567"},
+		{UUID: "id3", Speaker: "A", Text: "My email is user@example.com"},
 	}
 
 	// Create a redaction engine with default settings
@@ -0,0 +1,32 @@
+package piiredact
+
+// Match confidence.
+//
+// Every match has a confidence in (0, 1]. Regex matches take their
+// pattern's PatternDef.Confidence and detector matches the Confidence of
+// their Detection; zero means unspecified and counts as 1, so builtin
+// patterns and detectors that report no score are always fully trusted.
+// A match is redacted only if its confidence reaches the threshold for its
+// label: the label's entry in Config.PatternMinConfidence if there is one,
+// otherwise Config.MinConfidence.
+
+// confidence returns the confidence of a match, treating unset as 1.
+func (e *RedactionEngine) confidence(m match) float64 {
+	c := m.confidence
+	if m.name == "" {
+		c = e.patterns[m.pattern].Confidence
+	}
+	if c <= 0 {
+		return 1
+	}
+	return c
+}
+
+// confident reports whether a match reaches its confidence threshold.
+func (e *RedactionEngine) confident(m match) bool {
+	threshold, ok := e.config.PatternMinConfidence[e.matchName(m)]
+	if !ok {
+		threshold = e.config.MinConfidence
+	}
+	return e.confidence(m) >= threshold
+}
@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// scoredDetector reports fixed words with fixed confidences
+type scoredDetector map[string]Detection
+
+func (d scoredDetector) Detect(text string) ([]Detection, error) {
+	var detections []Detection
+	for word, det := range d {
+		if i := strings.Index(text, word); i >= 0 {
+			det.Start, det.End, det.Value = i, i+len(word), word
+			detections = append(detections, det)
+		}
+	}
+	return detections, nil
+}
+
+// TestRedactionEngine_MinConfidence tests global and per-pattern confidence thresholds
+func TestRedactionEngine_MinConfidence(t *testing.T) {
+	input := "SSN 401-23-4567, account 12345678, Jane Doe in Paris"
+
+	testCases := []struct {
+		name     string
+		global   float64
+		patterns map[string]float64
+		expected string
+	}{
+		{"none", 0, nil, "SSN [SSN], account [ACCOUNT], [PERSON] in [LOCATION]"},
+		{"global", 0.5, nil, "SSN [SSN], account [ACCOUNT], [PERSON] in Paris"},
+		{"per pattern", 0.5, map[string]float64{"ACCOUNT": 0.9, "LOCATION": 0.2}, "SSN [SSN], account 12345678, [PERSON] in [LOCATION]"},
+		// Builtin patterns are fully trusted unless a threshold above 1 is set
+		{"strict", 1, map[string]float64{"PERSON": 0.8}, "SSN [SSN], account 12345678, [PERSON] in Paris"},
+		{"off", 0, map[string]float64{"SSN": 1.1}, "SSN 401-23-4567, account [ACCOUNT], [PERSON] in [LOCATION]"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.CustomPatterns = []PatternDef{
+			{Name: "ACCOUNT", Regex: regexp.MustCompile(`\b\d{8}\b`), Confidence: 0.6},
+		}
+		config.Detectors = []Detector{scoredDetector{
+			"Jane Doe": {PatternName: "PERSON", Confidence: 0.95},
+			"Paris":    {PatternName: "LOCATION", Confidence: 0.3},
+		}}
+		config.MinConfidence = tc.global
+		config.PatternMinConfidence = tc.patterns
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("%s: Expected: %s\nGot: %s", tc.name, tc.expected, result[0].Text)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package piiredact
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// cueWindow is how many bytes before a match are searched for a cue word.
+const cueWindow = 40
+
+// precededByCue returns a ContextValidate that accepts a match only if one
+// of cues appears, case-insensitively, within cueWindow bytes before it.
+func precededByCue(cues []string) func(text string, start, end int) bool {
+	return func(text string, start, end int) bool {
+		from := start - cueWindow
+		if from < 0 {
+			from = 0
+		}
+		before := strings.ToLower(text[from:start])
+		for _, cue := range cues {
+			if strings.Contains(before, cue) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// requireCue gates p on its cue words, keeping any existing context check.
+func requireCue(p PatternDef) PatternDef {
+	return withContext(p, precededByCue(p.cues))
+}
+
+// withContext adds check to p's context validation, keeping any existing
+// check so both must pass.
+func withContext(p PatternDef, check func(text string, start, end int) bool) PatternDef {
+	if existing := p.ContextValidate; existing != nil {
+		p.ContextValidate = func(text string, start, end int) bool {
+			return check(text, start, end) && existing(text, start, end)
+		}
+	} else {
+		p.ContextValidate = check
+	}
+	return p
+}
+
+// cueBefore finds the cue that directly precedes text[start:], such as
+// "password" in "my password is hunter2". Only spaces, tabs and at most one
+// of connectors (such as ":" or "is") may come between the cue and start,
+// and the cue must start a word. It returns the cue, whether a connector
+// was present, and whether a cue was found. Cues and connectors are
+// lowercase and matched case-insensitively.
+func cueBefore(text string, start int, cues, connectors []string) (cue string, connected, ok bool) {
+	from := max(start-cueWindow, 0)
+	before := strings.ToLower(strings.TrimRight(text[from:start], " \t"))
+
+	for _, connector := range connectors {
+		if strings.HasSuffix(before, connector) && startsWord(before, len(before)-len(connector)) {
+			before = strings.TrimRight(before[:len(before)-len(connector)], " \t")
+			connected = true
+			break
+		}
+	}
+	for _, cue := range cues {
+		if strings.HasSuffix(before, cue) && startsWord(before, len(before)-len(cue)) {
+			return cue, connected, true
+		}
+	}
+	return "", false, false
+}
+
+// startsWord reports whether text[i:] does not continue a word that starts
+// before i. Text starting with punctuation, like ":", never continues one.
+func startsWord(text string, i int) bool {
+	next, _ := utf8.DecodeRuneInString(text[i:])
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWordRune(next) || !isWordRune(prev)
+}
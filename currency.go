@@ -0,0 +1,32 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// currencySymbols are the symbols that mark a number as an amount.
+const currencySymbols = "$€£¥₹"
+
+// centsRegex matches the decimal cents that end an amount, as in ".00".
+var centsRegex = regexp.MustCompile(`^\.[0-9]{2}\b`)
+
+// currencyCheckedPatterns are the numeric patterns whose matches are
+// skipped inside amounts when Config.SkipCurrency is set.
+var currencyCheckedPatterns = map[string]bool{
+	"SSN": true, "ITIN": true, "CC": true, "PHONE": true, "ABA": true,
+	"BANK_INFO": true, "DL": true, "IMEI": true,
+}
+
+// notCurrency is a ContextValidate that rejects matches that are part of a
+// currency amount: preceded by a currency symbol, optionally followed by a
+// space, as in "$ 123456789", or followed by decimal cents, as in
+// "123456789.00".
+func notCurrency(text string, start, end int) bool {
+	before := strings.TrimSuffix(text[:start], " ")
+	if r, _ := utf8.DecodeLastRuneInString(before); r != utf8.RuneError && strings.ContainsRune(currencySymbols, r) {
+		return false
+	}
+	return !centsRegex.MatchString(text[end:])
+}
@@ -0,0 +1,40 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_SkipCurrency tests that dollar amounts are not taken for SSNs
+func TestRedactionEngine_SkipCurrency(t *testing.T) {
+	testCases := []struct {
+		input    string
+		strict   string
+		currency string
+	}{
+		{"Paid $123,456,789.00", "Paid $123,456,789.00", "Paid $123,456,789.00"},
+		{"Total due $401234567.00 today", "Total due $[SSN].00 today", "Total due $401234567.00 today"},
+		{"Wire € 401234567 now", "Wire € [SSN] now", "Wire € 401234567 now"},
+		{"Balance 401234567.25", "Balance [SSN].25", "Balance 401234567.25"},
+		{"Card charged £4111111111111111", "Card charged £[CC]", "Card charged £4111111111111111"},
+		// Numbers that are not amounts are still redacted
+		{"SSN 401-23-4567 owes $5.00", "SSN [SSN] owes $5.00", "SSN [SSN] owes $5.00"},
+		{"Call 404-555-1212.", "Call [PHONE].", "Call [PHONE]."},
+		{"Version 401234567.1", "Version [SSN].1", "Version [SSN].1"},
+	}
+
+	strict := NewRedactionEngine(DefaultConfig())
+	config := DefaultConfig()
+	config.SkipCurrency = true
+	currency := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := strict.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.strict {
+			t.Errorf("Default input: %s\nExpected: %s\nGot: %s", tc.input, tc.strict, result[0].Text)
+		}
+		result, _ = currency.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.currency {
+			t.Errorf("SkipCurrency input: %s\nExpected: %s\nGot: %s", tc.input, tc.currency, result[0].Text)
+		}
+	}
+}
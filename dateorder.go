@@ -0,0 +1,65 @@
+package piiredact
+
+import (
+	"regexp"
+	"time"
+)
+
+// DateOrder selects how the DOB pattern reads numeric dates, which differ
+// by region: "01/02/1990" is 2 January in the US but 1 February in most of
+// Europe.
+type DateOrder int
+
+const (
+	// DateMDY, the default, reads dates month first, as in "04/15/1985".
+	DateMDY DateOrder = iota
+
+	// DateDMY reads dates day first, as in "15/04/1985".
+	DateDMY
+
+	// DateYMD reads dates year first, as in "1985-04-15".
+	DateYMD
+)
+
+// dateRegexes match DOB values in each non-default order; DateMDY uses
+// the builtin pattern's regex.
+var dateRegexes = map[DateOrder]*regexp.Regexp{
+	DateDMY: regexp.MustCompile(`\b(?:0[1-9]|[12][0-9]|3[01])[/.-](?:0[1-9]|1[0-2])[/.-](?:19|20)\d{2}\b`),
+	DateYMD: regexp.MustCompile(`\b(?:19|20)\d{2}[/.-](?:0[1-9]|1[0-2])[/.-](?:0[1-9]|[12][0-9]|3[01])\b`),
+}
+
+// datePattern returns the DOB pattern for order. Outside DateMDY, matches
+// must also be real calendar dates, so "31/04/1985" is not redacted.
+func datePattern(p PatternDef, order DateOrder) PatternDef {
+	re, ok := dateRegexes[order]
+	if !ok {
+		return p
+	}
+	p.Regex = re
+	p.Validate = func(value string) bool {
+		layout, ok := dateLayout(value, order)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(layout, value)
+		return err == nil
+	}
+	return p
+}
+
+// dateLayout returns the time layout of a DOB match in the given order,
+// keeping the match's separators. It returns false if the value does not
+// have the ten characters of a DOB match.
+func dateLayout(value string, order DateOrder) (string, bool) {
+	if len(value) != 10 {
+		return "", false
+	}
+	switch order {
+	case DateDMY:
+		return "02" + value[2:3] + "01" + value[5:6] + "2006", true
+	case DateYMD:
+		return "2006" + value[4:5] + "01" + value[7:8] + "02", true
+	default:
+		return "01" + value[2:3] + "02" + value[5:6] + "2006", true
+	}
+}
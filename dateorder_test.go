@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_DateOrder tests reading DOB values in each field order
+func TestRedactionEngine_DateOrder(t *testing.T) {
+	testCases := []struct {
+		order    DateOrder
+		input    string
+		expected string
+	}{
+		{DateMDY, "born 04/15/1985", "born [DOB]"},
+		{DateMDY, "born 13/01/1990", "born 13/01/1990"},
+		{DateDMY, "born 13/01/1990", "born [DOB]"},
+		{DateDMY, "born 15.04.1985", "born [DOB]"},
+		{DateDMY, "born 04/15/1985", "born 04/15/1985"},
+		// Day-first dates must exist on the calendar
+		{DateDMY, "born 31/04/1985", "born 31/04/1985"},
+		{DateDMY, "born 29/02/2024", "born [DOB]"},
+		{DateDMY, "born 29/02/2023", "born 29/02/2023"},
+		{DateYMD, "born 1985-04-15", "born [DOB]"},
+		{DateYMD, "born 1990/13/01", "born 1990/13/01"},
+		{DateYMD, "born 1985-02-30", "born 1985-02-30"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.DateOrder = tc.order
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Order %d: Input: %s\nExpected: %s\nGot: %s", tc.order, tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestShiftDate_DateOrder tests shifting dates read day or year first
+func TestShiftDate_DateOrder(t *testing.T) {
+	testCases := []struct {
+		value    string
+		order    DateOrder
+		expected string
+		ok       bool
+	}{
+		{"13/01/1990", DateDMY, "23/01/1990", true},
+		{"1990-01-13", DateYMD, "1990-01-23", true},
+		{"13/01/1990", DateMDY, "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := shiftDate(tc.value, 10, tc.order)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("shiftDate(%q, 10, %d) = %q, %v; expected %q, %v", tc.value, tc.order, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package piiredact
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+// Date shifting.
+//
+// With Config.DateShift, DOB matches are replaced by the same date moved by
+// a fixed number of days rather than by a label. The offset is drawn at
+// random when the engine is created, is never zero, and is at most
+// Config.DateShiftDays days either way. Every date redacted by one engine
+// moves by the same offset, so intervals between dates (ages at an event,
+// days between visits) are preserved while absolute dates are not. Use one
+// engine per patient or record set; a new engine draws a new offset, and
+// the offset is not recoverable from the output.
+
+// defaultDateShiftDays is used when Config.DateShiftDays is not set.
+const defaultDateShiftDays = 365
+
+// dateShiftOffset draws a random non-zero offset in [-maxDays, maxDays].
+func dateShiftOffset(maxDays int) int {
+	if maxDays <= 0 {
+		maxDays = defaultDateShiftDays
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(2*maxDays)))
+	if err != nil {
+		panic("piiredact: reading random date shift: " + err.Error())
+	}
+
+	// Map [0, 2*maxDays) onto [-maxDays, -1] and [1, maxDays]
+	offset := int(n.Int64()) - maxDays
+	if offset >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// shiftDate moves a DOB match such as "04/15/1985", read in the given
+// order, by days, keeping its format. It returns false if the value is not
+// a real calendar date.
+func shiftDate(value string, days int, order DateOrder) (string, bool) {
+	layout, ok := dateLayout(value, order)
+	if !ok {
+		return "", false
+	}
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		return "", false
+	}
+	return date.AddDate(0, 0, days).Format(layout), true
+}
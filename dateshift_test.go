@@ -0,0 +1,60 @@
+package piiredact
+
+import (
+	"testing"
+	"time"
+)
+
+// TestShiftDate tests shifting dates while keeping their format
+func TestShiftDate(t *testing.T) {
+	testCases := []struct {
+		value    string
+		days     int
+		expected string
+		ok       bool
+	}{
+		{"04/15/1985", 10, "04/25/1985", true},
+		{"12-31-1999", 1, "01-01-2000", true},
+		{"03.01.2024", -1, "02.29.2024", true},
+		{"02/31/1985", 10, "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := shiftDate(tc.value, tc.days, DateMDY)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("shiftDate(%q, %d) = %q, %v; expected %q, %v", tc.value, tc.days, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+// TestRedactionEngine_DateShift tests that all dates move by the same offset
+func TestRedactionEngine_DateShift(t *testing.T) {
+	config := DefaultConfig()
+	config.DateShift = true
+	config.DateShiftDays = 30
+	engine := NewRedactionEngine(config)
+
+	if engine.dateShift == 0 || engine.dateShift < -30 || engine.dateShift > 30 {
+		t.Fatalf("Offset %d outside [-30, 30] or zero", engine.dateShift)
+	}
+
+	result, _ := engine.Process([]Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Born 04/15/1985, SSN 123-45-6789"},
+		{UUID: "id2", Speaker: "A", Text: "Admitted 04/25/1985"},
+	})
+
+	born, err1 := time.Parse("01/02/2006", result[0].Text[5:15])
+	admitted, err2 := time.Parse("01/02/2006", result[1].Text[9:19])
+	if err1 != nil || err2 != nil {
+		t.Fatalf("Dates not preserved as dates: %q, %q", result[0].Text, result[1].Text)
+	}
+	if result[0].Text[5:15] == "04/15/1985" {
+		t.Error("Date was not shifted")
+	}
+	if interval := admitted.Sub(born); interval != 10*24*time.Hour {
+		t.Errorf("Interval not preserved: got %v", interval)
+	}
+	if result[0].Text[15:] != ", SSN [SSN]" {
+		t.Errorf("Other patterns should still be labelled: %s", result[0].Text)
+	}
+}
@@ -0,0 +1,64 @@
+package piiredact
+
+// RedactionDetail describes one redaction made by RedactDetailed, with the
+// value in every representation the engine can produce.
+type RedactionDetail struct {
+	Detection        // What was found and where, in offsets of the input text
+	Mask      string // Shape-preserving mask as in ModeMask, e.g. "XXX-XX-6789"
+	Token     string // Stable keyed token, e.g. "SSN_3f9a0c1d2b7e", as used by ModeToken
+}
+
+// RedactDetailed redacts text according to the configured Mode and also
+// returns, for each redaction, the original value, its mask and its token,
+// all computed in a single detection pass. This suits callers that show
+// masked values to people while storing tokens for analytics.
+//
+// Details are in the order the values appear in text. Tokens use the same
+// key as ModeToken, in the global namespace, so they match tokens produced
+// elsewhere by an engine with the same TokenKey; they are not written to
+// the token sidecar unless Mode is ModeToken. Metrics and audit records
+// are updated as for Process.
+func (e *RedactionEngine) RedactDetailed(text string) (string, []RedactionDetail) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	r := e.newRedaction()
+	defer r.release()
+	c := e.redactChunkWith(Chunk{Text: text}, r)
+
+	details := make([]RedactionDetail, len(r.matches))
+	for i, m := range r.matches {
+		d := e.detection(text, m)
+		details[i] = RedactionDetail{
+			Detection: d,
+			Mask:      e.mask(d.Value),
+			Token:     e.token(d.PatternName, "", d.Value),
+		}
+	}
+	return c.Text, details
+}
+
+// Detect returns the PII in text without redacting it: the values that
+// Process would replace, in the order they appear and with overlaps
+// resolved in the same way. It does not update metrics or write audit
+// records; OnMatch is still consulted.
+//
+// If a Detector fails, such as an NER service returning
+// ErrDetectorUnavailable, Detect still returns what the patterns and the
+// other detectors found, along with the detectors' errors joined. An empty
+// result with an error does not mean text holds no PII.
+func (e *RedactionEngine) Detect(text string) ([]Detection, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.detectText(text)
+}
+
+// detectText implements Detect, with the caller holding configMu for
+// reading.
+func (e *RedactionEngine) detectText(text string) ([]Detection, error) {
+	matches, err := e.resolvedMatches(text, nil)
+	detections := make([]Detection, len(matches))
+	for i, m := range matches {
+		detections[i] = e.detection(text, m)
+	}
+	return detections, err
+}
@@ -0,0 +1,104 @@
+package piiredact
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_RedactDetailed tests getting masks and tokens in one pass
+func TestRedactionEngine_RedactDetailed(t *testing.T) {
+	config := DefaultConfig()
+	config.TokenKey = []byte("test-key")
+	engine := NewRedactionEngine(config)
+
+	text := "SSN 123-45-6789, email jane@example.com"
+	redacted, details := engine.RedactDetailed(text)
+
+	if expected := "SSN [SSN], email [EMAIL]"; redacted != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, redacted)
+	}
+	if len(details) != 2 {
+		t.Fatalf("Expected 2 details, got %d", len(details))
+	}
+
+	ssn := details[0]
+	if ssn.PatternName != "SSN" || ssn.Value != "123-45-6789" || text[ssn.Start:ssn.End] != ssn.Value {
+		t.Errorf("Unexpected SSN detection: %+v", ssn.Detection)
+	}
+	if ssn.Mask != "XXX-XX-6789" {
+		t.Errorf("Expected mask XXX-XX-6789, got %s", ssn.Mask)
+	}
+	if ssn.Token != engine.token("SSN", "", "123-45-6789") {
+		t.Errorf("Unexpected token %s", ssn.Token)
+	}
+	if details[1].PatternName != "EMAIL" || details[1].Value != "jane@example.com" {
+		t.Errorf("Unexpected EMAIL detection: %+v", details[1].Detection)
+	}
+
+	// Tokens agree with ModeToken output under the same key
+	config.Mode = ModeToken
+	tokenized, _ := NewRedactionEngine(config).Process([]Chunk{{UUID: "u", Text: text}})
+	if expected := "SSN [" + ssn.Token + "], email [" + details[1].Token + "]"; tokenized[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, tokenized[0].Text)
+	}
+}
+
+// TestRedactionEngine_Detect tests reporting detections without redacting
+func TestRedactionEngine_Detect(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{Name: "ACCOUNT", Regex: regexp.MustCompile(`\bACCT-\d{6}\b`), Confidence: 0.7, Sensitivity: SensitivityLow},
+	}
+	config.Detectors = []Detector{scoredDetector{"Jane Doe": {PatternName: "PERSON", Confidence: 0.9}}}
+	engine := NewRedactionEngine(config)
+
+	text := "Jane Doe, SSN 401-23-4567, ACCT-123456, 10.0.0.1"
+	expected := []Detection{
+		{PatternName: "PERSON", Start: 0, End: 8, Value: "Jane Doe", Confidence: 0.9, Sensitivity: SensitivityHigh},
+		{PatternName: "SSN", Start: 14, End: 25, Value: "401-23-4567", Confidence: 1, Sensitivity: SensitivityHigh},
+		{PatternName: "ACCOUNT", Start: 27, End: 38, Value: "ACCT-123456", Confidence: 0.7, Sensitivity: SensitivityLow},
+		{PatternName: "IP", Start: 40, End: 48, Value: "10.0.0.1", Confidence: 1, Sensitivity: SensitivityLow},
+	}
+	if got, err := engine.Detect(text); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("Detect() = %+v, %v\nexpected %+v", got, err, expected)
+	}
+
+	// RedactDetailed reports the same detections
+	_, details := engine.RedactDetailed(text)
+	for i, d := range details {
+		if d.Detection != expected[i] {
+			t.Errorf("RedactDetailed detection %d = %+v, expected %+v", i, d.Detection, expected[i])
+		}
+	}
+
+	// Detect leaves metrics alone
+	engine.ResetMetrics()
+	engine.Detect(text)
+	metrics := engine.GetMetrics()
+	if metrics.RedactedItems["SSN"] != 0 || metrics.RedactedItems["PERSON"] != 0 || metrics.ProcessedChunks != 0 {
+		t.Errorf("Detect updated metrics: %+v", metrics.RedactedItems)
+	}
+}
+
+// TestRedactionEngine_DetectDetectorError tests that detector failures are
+// returned alongside what the patterns still found
+func TestRedactionEngine_DetectDetectorError(t *testing.T) {
+	config := DefaultConfig()
+	config.Detectors = []Detector{failingDetector{}}
+	engine := NewRedactionEngine(config)
+
+	detections, err := engine.Detect("fail: SSN 401-23-4567")
+	if err == nil || !strings.Contains(err.Error(), "model unavailable") {
+		t.Errorf("Expected the detector error, got %v", err)
+	}
+	if len(detections) != 1 || detections[0].Value != "401-23-4567" {
+		t.Errorf("Expected the SSN despite the failure, got %+v", detections)
+	}
+
+	// No PII and no failure gives no error
+	if detections, err := engine.Detect("all clear"); err != nil || len(detections) != 0 {
+		t.Errorf("Expected no detections and no error, got %+v, %v", detections, err)
+	}
+}
@@ -0,0 +1,246 @@
+package piiredact
+
+import (
+	"errors"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// match is a validated candidate detection in a chunk's original text.
+type match struct {
+	start, end  int     // Byte offsets of the match in the original text
+	pattern     int     // Index of the matching pattern in the engine's pattern list
+	name        string  // Entity type reported by a detector; empty for regex patterns
+	replacement string  // Text that replaced the match, set by redactText
+	confidence  float64 // Confidence reported by a detector; unused for regex patterns
+}
+
+// matchName returns the label of a match: the detector's entity type, or
+// the name of the pattern that found it.
+func (e *RedactionEngine) matchName(m match) string {
+	if m.name != "" {
+		return m.name
+	}
+	return e.patterns[m.pattern].Name
+}
+
+// Texts longer than largeTextWindow are scanned in windows of that size,
+// each extended by largeTextOverlap on both sides so that any value up to
+// largeTextOverlap bytes long is seen whole, with its context, by the
+// window it starts in.
+const (
+	largeTextWindow  = 1 << 20
+	largeTextOverlap = 64 << 10
+)
+
+// findMatches runs every active pattern against text and returns the
+// candidates that are long enough, pass validation and are not vetoed by
+// Config.OnMatch. Matches may overlap. Detector failures are joined into
+// the error; the matches of every other pattern are still returned.
+//
+// Very large texts are scanned window by window, which bounds the memory
+// the regex engine and detectors need for a single pass; each match is
+// kept only by the window it starts in, so none is reported twice.
+//
+// OnMatch is called from whichever goroutine redacts the chunk, so with
+// MaxConcurrency above 1 it runs concurrently on worker goroutines.
+func (e *RedactionEngine) findMatches(text string, skip []bool) ([]match, error) {
+	if len(text) <= largeTextWindow+2*largeTextOverlap {
+		return e.findMatchesIn(text, 0, len(text), skip)
+	}
+
+	var matches []match
+	var errs []error
+	for from := 0; from < len(text); from += largeTextWindow {
+		windowMatches, err := e.findMatchesIn(text, from, min(from+largeTextWindow, len(text)), skip)
+		matches = append(matches, windowMatches...)
+		errs = append(errs, err)
+	}
+	return matches, errors.Join(errs...)
+}
+
+// findMatchesIn returns the matches that start in text[from:to], scanning
+// that range plus largeTextOverlap bytes on either side. Offsets are
+// relative to the whole text, which is also what context checks and
+// OnMatch see. Patterns whose entry in skip is true are not run.
+func (e *RedactionEngine) findMatchesIn(text string, from, to int, skip []bool) ([]match, error) {
+	lo, hi := 0, len(text)
+	if from > 0 || to < len(text) {
+		lo, hi = max(from-largeTextOverlap, 0), min(to+largeTextOverlap, len(text))
+	}
+	window := text[lo:hi]
+
+	present := e.requires.present(window)
+
+	var matches []match
+	var errs []error
+	for i, p := range e.patterns {
+		// Skip patterns excluded for the chunk's language
+		if skip != nil && skip[i] {
+			continue
+		}
+
+		// Skip patterns whose required characters are absent
+		if !e.requires.met(i, window, present) {
+			continue
+		}
+		candidates, err := e.candidates(p, window)
+		errs = append(errs, err)
+		for _, m := range candidates {
+			m.start += lo
+			m.end += lo
+			if m.start < from || m.start >= to {
+				continue
+			}
+			m.pattern = i
+			if !e.accepts(text, m) {
+				continue
+			}
+			value := text[m.start:m.end]
+			if e.config.OnMatch != nil && !e.config.OnMatch(e.matchName(m), value, m.start, m.end, text) {
+				continue
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches, errors.Join(errs...)
+}
+
+// accepts reports whether candidate m of pattern m.pattern in text passes
+// the confidence, length and validation checks. OnMatch is not consulted.
+func (e *RedactionEngine) accepts(text string, m match) bool {
+	p := e.patterns[m.pattern]
+	value := text[m.start:m.end]
+
+	// Matches the caller does not trust enough are left alone
+	if !e.confident(m) {
+		return false
+	}
+
+	// Short matches are likely false positives
+	if p.MinLength > 0 && utf8.RuneCountInString(value) < p.MinLength {
+		return false
+	}
+
+	// Skip validation if no validation function or validation passes
+	if p.Validate != nil && !p.Validate(value) {
+		return false
+	}
+	return p.ContextValidate == nil || p.ContextValidate(text, m.start, m.end)
+}
+
+// candidates returns the unvalidated matches of one pattern, asking its
+// detector if it wraps one.
+func (e *RedactionEngine) candidates(p PatternDef, text string) ([]match, error) {
+	if p.detector != nil {
+		return e.detect(p.detector, text)
+	}
+
+	var matches []match
+	for _, m := range p.findAll(text) {
+		matches = append(matches, match{start: m[0], end: m[1]})
+	}
+	return matches, nil
+}
+
+// resolvedMatches returns the matches to redact in text: every pattern's
+// matches, with overlaps settled, edges trimmed if configured and pattern
+// groups merged. They are sorted, disjoint and in original-text offsets.
+// The error joins any detector failures, as for findMatches.
+func (e *RedactionEngine) resolvedMatches(text string, skip []bool) ([]match, error) {
+	found, err := e.findNormalizedMatches(text, skip)
+	matches := e.resolveOverlaps(found)
+	if e.config.TrimMatchWhitespace {
+		matches = trimMatches(text, matches)
+	}
+	return e.mergeGroups(text, matches), err
+}
+
+// detection describes a match of text as a Detection.
+func (e *RedactionEngine) detection(text string, m match) Detection {
+	return Detection{
+		PatternName: e.matchName(m),
+		Start:       m.start,
+		End:         m.end,
+		Value:       text[m.start:m.end],
+		Confidence:  e.confidence(m),
+		Sensitivity: e.patterns[m.pattern].Sensitivity.effective(),
+	}
+}
+
+// resolveOverlaps merges overlapping matches so no byte is redacted twice.
+//
+// Each run of mutually overlapping matches collapses into a single match
+// covering their union, so no part of any detected value is left in the
+// clear. The run is labelled by its highest-priority pattern; ties go to
+// the pattern that appears first in the engine's pattern list (builtins,
+// then dictionaries, then custom patterns). The result is sorted by start
+// offset.
+func (e *RedactionEngine) resolveOverlaps(matches []match) []match {
+	if len(matches) < 2 {
+		return matches
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+
+	resolved := matches[:1]
+	for _, m := range matches[1:] {
+		last := &resolved[len(resolved)-1]
+		if m.start >= last.end {
+			resolved = append(resolved, m)
+			continue
+		}
+
+		// Overlap: widen the current run and keep the stronger label
+		if m.end > last.end {
+			last.end = m.end
+		}
+		if e.outranks(m.pattern, last.pattern) {
+			last.pattern = m.pattern
+			last.name = m.name
+		}
+	}
+	return resolved
+}
+
+// outranks reports whether pattern a should label an overlap over pattern b.
+func (e *RedactionEngine) outranks(a, b int) bool {
+	pa, pb := e.patterns[a].Priority, e.patterns[b].Priority
+	if pa != pb {
+		return pa > pb
+	}
+	return a < b
+}
+
+// trimMatches shrinks each match so it neither starts nor ends with
+// whitespace, dropping matches that were whitespace only. The whitespace
+// stays in the text around the replacement instead of being swallowed.
+func trimMatches(text string, matches []match) []match {
+	trimmed := matches[:0]
+	for _, m := range matches {
+		for m.start < m.end {
+			r, size := utf8.DecodeRuneInString(text[m.start:m.end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			m.start += size
+		}
+		for m.end > m.start {
+			r, size := utf8.DecodeLastRuneInString(text[m.start:m.end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			m.end -= size
+		}
+		if m.start < m.end {
+			trimmed = append(trimmed, m)
+		}
+	}
+	return trimmed
+}
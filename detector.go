@@ -0,0 +1,58 @@
+package piiredact
+
+import (
+	"unicode/utf8"
+)
+
+// Detection is a single piece of PII found in a text. It is the one type
+// used to describe detections throughout the package: Detectors return
+// them, and Detect, RedactDetailed and the other reporting methods return
+// them with every field filled in.
+type Detection struct {
+	PatternName string      // Entity or pattern type, used as the redaction label
+	Start       int         // Byte offset of the first byte of the value
+	End         int         // Byte offset just past the value
+	Value       string      // The detected text, text[Start:End]
+	Confidence  float64     // Confidence in (0, 1]; detectors may leave it 0, meaning 1
+	Sensitivity Sensitivity // Tier of the pattern, never unspecified in engine results; ignored from detectors
+}
+
+// Detector finds PII that patterns cannot express, such as personal names
+// found by a machine learning model.
+//
+// Detections are merged with pattern matches and redacted under their own
+// PatternName. A Detector must be safe for concurrent use. If Detect
+// returns an error the engine logs it and redacts the text with its
+// patterns alone, so a failing detector never blocks redaction; with
+// Config.FailClosed the whole text is replaced by a "REDACTED" label
+// instead. Either way Process reports the failure as a
+// ChunkDetectorFailed problem in a ProcessError.
+type Detector interface {
+	Detect(text string) ([]Detection, error)
+}
+
+// detect runs an external detector and converts its detections to
+// candidate matches, discarding any with offsets outside text or inside a
+// UTF-8 sequence. If the detector fails, it logs and returns the error
+// with no matches.
+func (e *RedactionEngine) detect(d Detector, text string) ([]match, error) {
+	detections, err := d.Detect(text)
+	if err != nil {
+		if e.config.Logging && e.logger != nil {
+			e.logger.Printf("Detector failed, using patterns only: %v", err)
+		}
+		return nil, err
+	}
+
+	var matches []match
+	for _, det := range detections {
+		if det.PatternName == "" || det.Start < 0 || det.End > len(text) || det.Start >= det.End {
+			continue
+		}
+		if !utf8.RuneStart(text[det.Start]) || (det.End < len(text) && !utf8.RuneStart(text[det.End])) {
+			continue
+		}
+		matches = append(matches, match{start: det.Start, end: det.End, name: det.PatternName, confidence: det.Confidence})
+	}
+	return matches, nil
+}
@@ -0,0 +1,117 @@
+package piiredact
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// DetectStream reads blocks of streamBlock bytes and keeps the last
+// streamLookback bytes of each scan for the next one, so any value up to
+// streamLookback bytes long is seen whole, with its context, by the scan
+// in which it starts.
+const (
+	streamBlock    = 64 << 10
+	streamLookback = 4 << 10
+)
+
+// DetectStream reads r to the end and calls handler with each value
+// Detect would report, as soon as it is found, without producing any
+// redacted output. It suits alerting pipelines that only need to know that
+// PII was seen.
+//
+// Start and End are byte offsets from the beginning of the stream, not of
+// any one read. r is scanned in blocks, each overlapping the previous one
+// by a lookback buffer, so values that straddle a read or block boundary
+// are still found whole and reported once. A value is only reported once
+// enough text after it has been read to be sure it is complete, which
+// means detections lag the input by up to the lookback size; the rest are
+// reported at EOF. Detections are delivered in stream order.
+//
+// Values longer than the lookback buffer (4 KiB) may be missed or reported
+// in part if they cross a block boundary. Like Detect, DetectStream
+// updates no metrics and writes no audit records. It returns the first
+// read error other than io.EOF, after reporting the detections in the text
+// read before it.
+//
+// If a Detector fails, scanning goes on with the patterns and the other
+// detectors, and the first detector error is returned once the stream
+// ends, joined with any read error, so "no PII" can be told apart from
+// "detector unavailable".
+func (e *RedactionEngine) DetectStream(r io.Reader, handler func(Detection)) error {
+	var (
+		buf       []byte // Unreported text plus up to streamLookback bytes before it
+		base      int    // Stream offset of buf[0]
+		reported  int    // Stream offset up to which values have been reported
+		detectErr error  // First detector error
+	)
+	scan := func(limit int) {
+		if err := e.reportStream(buf, base, &reported, limit, handler); err != nil && detectErr == nil {
+			detectErr = err
+		}
+	}
+	block := make([]byte, streamBlock)
+
+	for {
+		n, err := io.ReadFull(r, block)
+		buf = append(buf, block[:n]...)
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			scan(len(buf))
+			if detectErr != nil {
+				return errors.Join(err, detectErr)
+			}
+			return err
+		}
+
+		// Report values that start before the lookback at the end of buf;
+		// those after it may not have been read in full yet
+		limit := len(buf)
+		if !eof {
+			limit = runeStart(buf, max(len(buf)-streamLookback, 0))
+		}
+		scan(limit)
+		if eof {
+			return detectErr
+		}
+
+		// Keep the lookback before limit as context for the next scan
+		keep := runeStart(buf, max(limit-streamLookback, 0))
+		base += keep
+		buf = append(buf[:0], buf[keep:]...)
+	}
+}
+
+// reportStream scans buf, which starts at stream offset base, and reports
+// the detections that start before buf[limit] and at or after *reported,
+// advancing *reported past each one. It returns the error of any failing
+// detector.
+func (e *RedactionEngine) reportStream(buf []byte, base int, reported *int, limit int, handler func(Detection)) error {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	text := string(buf)
+	matches, err := e.resolvedMatches(text, nil)
+	for _, m := range matches {
+		if m.start >= limit {
+			break
+		}
+		if base+m.start < *reported {
+			continue
+		}
+		d := e.detection(text, m)
+		d.Start += base
+		d.End += base
+		*reported = d.End
+		handler(d)
+	}
+	return err
+}
+
+// runeStart moves i back to the start of the UTF-8 sequence it falls in,
+// so buf is never cut inside a character.
+func runeStart(buf []byte, i int) int {
+	for i > 0 && i < len(buf) && !utf8.RuneStart(buf[i]) {
+		i--
+	}
+	return i
+}
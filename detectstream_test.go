@@ -0,0 +1,104 @@
+package piiredact
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// collectStream runs DetectStream over text and returns the detections
+func collectStream(t *testing.T, engine *RedactionEngine, r io.Reader) []Detection {
+	t.Helper()
+	var detections []Detection
+	if err := engine.DetectStream(r, func(d Detection) { detections = append(detections, d) }); err != nil {
+		t.Fatalf("DetectStream returned error: %v", err)
+	}
+	return detections
+}
+
+// TestRedactionEngine_DetectStream tests that stream detections match Detect
+func TestRedactionEngine_DetectStream(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	for _, text := range []string{
+		"",
+		"no PII here",
+		"SSN 401-23-4567 and jane@example.com",
+		manyPhonesChunk(10000), // Several blocks
+	} {
+		expected, _ := engine.Detect(text)
+		got := collectStream(t, engine, iotest.HalfReader(strings.NewReader(text)))
+		if len(got) != len(expected) {
+			t.Fatalf("Text of %d bytes: expected %d detections, got %d", len(text), len(expected), len(got))
+		}
+		if len(expected) > 0 && !reflect.DeepEqual(got, expected) {
+			t.Errorf("Text of %d bytes: detections differ from Detect", len(text))
+		}
+	}
+}
+
+// TestRedactionEngine_DetectStreamBoundary tests values that straddle a block boundary
+func TestRedactionEngine_DetectStreamBoundary(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	for _, offset := range []int{1, 5, 10, 11, streamLookback - 3} {
+		prefix := strings.Repeat("a", streamBlock-offset) + " "
+		text := prefix + "401-23-4567 é jane@example.com " + strings.Repeat("b", 100)
+
+		got := collectStream(t, engine, strings.NewReader(text))
+		if len(got) != 2 {
+			t.Fatalf("Offset %d: expected 2 detections, got %+v", offset, got)
+		}
+		if got[0].PatternName != "SSN" || got[0].Start != len(prefix) || text[got[0].Start:got[0].End] != "401-23-4567" {
+			t.Errorf("Offset %d: unexpected SSN detection %+v", offset, got[0])
+		}
+		if got[1].PatternName != "EMAIL" || text[got[1].Start:got[1].End] != "jane@example.com" {
+			t.Errorf("Offset %d: unexpected EMAIL detection %+v", offset, got[1])
+		}
+	}
+}
+
+// TestRedactionEngine_DetectStreamError tests that read errors are returned
+// after the detections read before them
+func TestRedactionEngine_DetectStreamError(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	failure := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("SSN 401-23-4567 "), iotest.ErrReader(failure))
+
+	var detections []Detection
+	err := engine.DetectStream(r, func(d Detection) { detections = append(detections, d) })
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected %v, got %v", failure, err)
+	}
+	if len(detections) != 1 || detections[0].Value != "401-23-4567" {
+		t.Errorf("Expected the SSN before the error, got %+v", detections)
+	}
+}
+
+// TestRedactionEngine_DetectStreamDetectorError tests that detector
+// failures are returned after the detections the patterns still found
+func TestRedactionEngine_DetectStreamDetectorError(t *testing.T) {
+	config := DefaultConfig()
+	config.Detectors = []Detector{failingDetector{}}
+	engine := NewRedactionEngine(config)
+
+	var detections []Detection
+	err := engine.DetectStream(strings.NewReader("fail: SSN 401-23-4567"), func(d Detection) { detections = append(detections, d) })
+	if err == nil || !strings.Contains(err.Error(), "model unavailable") {
+		t.Errorf("Expected the detector error, got %v", err)
+	}
+	if len(detections) != 1 || detections[0].Value != "401-23-4567" {
+		t.Errorf("Expected the SSN despite the failure, got %+v", detections)
+	}
+
+	// A read error is returned alongside it
+	failure := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("fail: SSN 401-23-4567 "), iotest.ErrReader(failure))
+	err = engine.DetectStream(r, func(Detection) {})
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "model unavailable") {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package piiredact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// acMinTerms is the dictionary size at which literal matching switches from
+// a regex alternation to the Aho-Corasick automaton.
+const acMinTerms = 100
+
+// newDictionaryPattern builds a pattern that matches any of the given
+// literal terms, case-insensitively and on word boundaries.
+//
+// Small dictionaries are compiled into a single alternation, longest first
+// so that "Anna Lee" wins over "Anna". Once a dictionary reaches acMinTerms
+// entries it is loaded into an Aho-Corasick automaton instead, whose cost
+// per chunk is linear in the length of the text no matter how many terms
+// are loaded; overlapping hits are resolved leftmost-longest.
+//
+// Word boundaries are checked on Unicode letters and digits rather than
+// with \b, which only understands ASCII and would reject names such as "José".
+// It returns false if no usable terms are supplied.
+func newDictionaryPattern(name string, terms []string) (PatternDef, bool) {
+	// Normalize, de-duplicate and drop empty terms
+	seen := make(map[string]bool)
+	var cleaned []string
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		key := strings.ToLower(term)
+		if term == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, term)
+	}
+	if len(cleaned) == 0 {
+		return PatternDef{}, false
+	}
+
+	if len(cleaned) >= acMinTerms {
+		ac := newAhoCorasick(cleaned)
+		return PatternDef{
+			Name:  name,
+			terms: cleaned,
+			find: func(text string) [][]int {
+				var matches [][]int
+				for _, m := range ac.findAll(text) {
+					if isWordBoundary(text, m[0], m[1]) {
+						matches = append(matches, m)
+					}
+				}
+				return selectLeftmostLongest(matches)
+			},
+		}, true
+	}
+
+	re := alternationRegex(cleaned)
+	bounded := regexp.MustCompile(re.String() + `(?:[^\p{L}\p{Nd}_]|$)`)
+	return PatternDef{
+		Name:  name,
+		Regex: re,
+		terms: cleaned,
+		find: func(text string) [][]int {
+			return findBoundedTerms(bounded, text)
+		},
+	}, true
+}
+
+// findBoundedTerms returns the dictionary matches of bounded, an
+// alternation followed by a non-word character or the end of text, as
+// built by newDictionaryPattern. Requiring the boundary inside the regex
+// makes it fall back to a shorter term at the same start, so "Anna Leeds"
+// yields "Anna" rather than nothing, just as the Aho-Corasick path does.
+// A match glued to a word on its left is skipped one rune at a time.
+func findBoundedTerms(bounded *regexp.Regexp, text string) [][]int {
+	var matches [][]int
+	for pos := 0; pos < len(text); {
+		loc := bounded.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[2], pos+loc[3]
+		if isWordBoundary(text, start, end) {
+			matches = append(matches, []int{start, end})
+			pos = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		pos = start + size
+	}
+	return matches
+}
+
+// alternationRegex compiles terms into a case-insensitive alternation,
+// longest first so that the most specific term wins. The alternation is
+// the regex's only capturing group.
+func alternationRegex(terms []string) *regexp.Regexp {
+	sorted := append([]string(nil), terms...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	quoted := make([]string, len(sorted))
+	for i, term := range sorted {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	return regexp.MustCompile(`(?i)(` + strings.Join(quoted, "|") + `)`)
+}
+
+// isWordBoundary reports whether text[start:end] is not glued to a letter,
+// digit or underscore on either side.
+func isWordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordRune reports whether r is part of a word in any script.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,116 @@
+package piiredact
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestRedactionEngine_NameDictionary tests redacting names from a supplied roster
+func TestRedactionEngine_NameDictionary(t *testing.T) {
+	config := DefaultConfig()
+	config.NameDictionary = []string{"Anna", "Anna Lee", "josé garcía", " "}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"This is Anna Lee speaking", "This is [NAME] speaking"},
+		{"anna called earlier", "[NAME] called earlier"},
+		{"Ask José García about it", "Ask [NAME] about it"},
+		{"Annabelle is not on the list", "Annabelle is not on the list"},
+		{"Anna, call 555-123-4567", "[NAME], call [PHONE]"},
+	}
+
+	engine := NewRedactionEngine(config)
+
+	for _, tt := range tests {
+		result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: tt.input}})
+		if result[0].Text != tt.expected {
+			t.Errorf("Name dictionary failed for %q:\nExpected: %s\nGot: %s",
+				tt.input, tt.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_NameDictionarySizes tests that small (regex) and
+// large (Aho-Corasick) dictionaries pick the same terms
+func TestRedactionEngine_NameDictionarySizes(t *testing.T) {
+	small := []string{"Anna Lee", "Anna", "Lee"}
+	large := append([]string(nil), small...)
+	for i := len(large); i < acMinTerms; i++ {
+		large = append(large, fmt.Sprintf("Filler%dName", i))
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Anna Leeds called", "[NAME] Leeds called"},
+		{"Anna Lee called", "[NAME] called"},
+		{"xAnna Lee called", "xAnna [NAME] called"},
+		{"Anna,Anna Lee_x", "[NAME],[NAME] Lee_x"},
+	}
+
+	for _, names := range [][]string{small, large} {
+		config := DefaultConfig()
+		config.NameDictionary = names
+		engine := NewRedactionEngine(config)
+
+		for _, tt := range tests {
+			result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: tt.input}})
+			if result[0].Text != tt.expected {
+				t.Errorf("%d names, input %q:\nExpected: %s\nGot: %s", len(names), tt.input, tt.expected, result[0].Text)
+			}
+		}
+	}
+}
+
+// BenchmarkNameDictionary measures redaction with a large name roster
+func BenchmarkNameDictionary(b *testing.B) {
+	names := make([]string, 5000)
+	for i := range names {
+		names[i] = fmt.Sprintf("Person%dName", i)
+	}
+	config := DefaultConfig()
+	config.NameDictionary = names
+	engine := NewRedactionEngine(config)
+
+	chunk := Chunk{UUID: "id1", Speaker: "A", Text: "Please transfer me to Person4999Name, my number is 555-123-4567"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.redactChunk(chunk)
+	}
+}
+
+// TestRedactionEngine_LiteralOverlap tests denylist terms that sit inside regex matches
+func TestRedactionEngine_LiteralOverlap(t *testing.T) {
+	tests := []struct {
+		priority int
+		input    string
+		expected string
+	}{
+		// Builtins win ties, so the whole email or phone keeps its own label
+		{0, "Write to john.doe@example.com", "Write to [EMAIL]"},
+		{0, "Call 555-123-4567 today", "Call [PHONE] today"},
+		// A higher literal priority relabels the whole overlapping span
+		{1, "Write to john.doe@example.com", "Write to [DENYLIST]"},
+		{1, "Call 555-123-4567 today", "Call [DENYLIST] today"},
+		// Outside of any regex match the literal is redacted on its own
+		{0, "john said 123 times", "[DENYLIST] said [DENYLIST] times"},
+	}
+
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.Denylist = []string{"john", "123"}
+		config.LiteralPriority = tt.priority
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: tt.input}})
+		if result[0].Text != tt.expected {
+			t.Errorf("Literal overlap failed (priority %d):\nExpected: %s\nGot: %s",
+				tt.priority, tt.expected, result[0].Text)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package piiredact
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ConfigDiff describes how the effective patterns of two configurations
+// differ, as returned by DiffConfigs. Its String method renders it for
+// review, one change per line.
+type ConfigDiff struct {
+	Enabled       []string // Builtin patterns active in b but not in a
+	Disabled      []string // Builtin patterns active in a but not in b
+	OldFormat     string   // RedactionFormat of a, set only if it changed
+	NewFormat     string   // RedactionFormat of b, set only if it changed
+	AddedCustom   []string // Custom pattern names only in b
+	RemovedCustom []string // Custom pattern names only in a
+	ChangedCustom []string // Custom pattern names in both with a different regex
+}
+
+// DiffConfigs compares the patterns two configurations would run, so a
+// change can be reviewed before it is deployed.
+//
+// Builtin patterns are compared by whether they end up active, after
+// EnabledPatterns defaults and DisableBuiltins are applied, so an empty
+// EnabledPatterns map and one listing every builtin compare equal. Custom
+// patterns are matched by Name and compared by regex source. Names are
+// listed in builtin order for builtins and in config order for custom
+// patterns.
+func DiffConfigs(a, b Config) ConfigDiff {
+	var d ConfigDiff
+
+	for _, p := range builtinPatterns {
+		d.compareBuiltin(p.Name, builtinEnabled(a, p.Name, false), builtinEnabled(b, p.Name, false))
+	}
+	for _, p := range optionalPatterns {
+		d.compareBuiltin(p.Name, builtinEnabled(a, p.Name, true), builtinEnabled(b, p.Name, true))
+	}
+
+	if a.RedactionFormat != b.RedactionFormat {
+		d.OldFormat, d.NewFormat = a.RedactionFormat, b.RedactionFormat
+	}
+
+	before := customRegexes(a.CustomPatterns)
+	after := customRegexes(b.CustomPatterns)
+	for _, p := range b.CustomPatterns {
+		old, ok := before[p.Name]
+		switch {
+		case !ok:
+			d.AddedCustom = appendOnce(d.AddedCustom, p.Name)
+		case old != after[p.Name]:
+			d.ChangedCustom = appendOnce(d.ChangedCustom, p.Name)
+		}
+	}
+	for _, p := range a.CustomPatterns {
+		if _, ok := after[p.Name]; !ok {
+			d.RemovedCustom = appendOnce(d.RemovedCustom, p.Name)
+		}
+	}
+	return d
+}
+
+// compareBuiltin records a builtin pattern whose active state changed.
+func (d *ConfigDiff) compareBuiltin(name string, inA, inB bool) {
+	switch {
+	case inB && !inA:
+		d.Enabled = append(d.Enabled, name)
+	case inA && !inB:
+		d.Disabled = append(d.Disabled, name)
+	}
+}
+
+// customRegexes maps custom pattern names to their regex source; if a name
+// is used twice the last pattern wins, as it is the last to be applied.
+func customRegexes(patterns []PatternDef) map[string]string {
+	regexes := make(map[string]string, len(patterns))
+	for _, p := range patterns {
+		source := ""
+		if p.Regex != nil {
+			source = p.Regex.String()
+		}
+		regexes[p.Name] = source
+	}
+	return regexes
+}
+
+// appendOnce appends name to names unless it is already listed.
+func appendOnce(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// Empty reports whether the two configurations run the same patterns.
+func (d ConfigDiff) Empty() bool {
+	return len(d.Enabled) == 0 && len(d.Disabled) == 0 && d.OldFormat == d.NewFormat &&
+		len(d.AddedCustom) == 0 && len(d.RemovedCustom) == 0 && len(d.ChangedCustom) == 0
+}
+
+// String renders the diff one change per line, such as
+//
+//   - pattern IMEI
+//   - pattern DL
+//     ~ format "[%s]" -> "<%s>"
+//   - custom EMPLOYEE_ID
+//
+// or "no changes" if the diff is empty.
+func (d ConfigDiff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+
+	var lines []string
+	for _, name := range d.Enabled {
+		lines = append(lines, "+ pattern "+name)
+	}
+	for _, name := range d.Disabled {
+		lines = append(lines, "- pattern "+name)
+	}
+	if d.OldFormat != d.NewFormat {
+		lines = append(lines, fmt.Sprintf("~ format %q -> %q", d.OldFormat, d.NewFormat))
+	}
+	for _, name := range d.AddedCustom {
+		lines = append(lines, "+ custom "+name)
+	}
+	for _, name := range d.RemovedCustom {
+		lines = append(lines, "- custom "+name)
+	}
+	for _, name := range d.ChangedCustom {
+		lines = append(lines, "~ custom "+name)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+package piiredact
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// TestDiffConfigs tests comparing the effective patterns of two configs
+func TestDiffConfigs(t *testing.T) {
+	a := DefaultConfig()
+	a.CustomPatterns = []PatternDef{
+		{Name: "EMPLOYEE_ID", Regex: regexp.MustCompile(`\bEMP-\d{6}\b`)},
+		{Name: "TICKET", Regex: regexp.MustCompile(`\bTCK-\d+\b`)},
+	}
+
+	b := DefaultConfig()
+	b.EnabledPatterns["DL"] = false
+	b.EnabledPatterns["IMEI"] = true
+	b.RedactionFormat = "<%s>"
+	b.CustomPatterns = []PatternDef{
+		{Name: "EMPLOYEE_ID", Regex: regexp.MustCompile(`\bEMP-\d{7}\b`)},
+		{Name: "BADGE", Regex: regexp.MustCompile(`\bB\d{5}\b`)},
+	}
+
+	expected := ConfigDiff{
+		Enabled:       []string{"IMEI"},
+		Disabled:      []string{"DL"},
+		OldFormat:     "[%s]",
+		NewFormat:     "<%s>",
+		AddedCustom:   []string{"BADGE"},
+		RemovedCustom: []string{"TICKET"},
+		ChangedCustom: []string{"EMPLOYEE_ID"},
+	}
+	d := DiffConfigs(a, b)
+	if !reflect.DeepEqual(d, expected) {
+		t.Errorf("DiffConfigs() = %+v, expected %+v", d, expected)
+	}
+
+	expectedText := "+ pattern IMEI\n- pattern DL\n~ format \"[%s]\" -> \"<%s>\"\n+ custom BADGE\n- custom TICKET\n~ custom EMPLOYEE_ID"
+	if d.String() != expectedText {
+		t.Errorf("String() = %q, expected %q", d.String(), expectedText)
+	}
+
+	// An empty EnabledPatterns map enables the same builtins as DefaultConfig
+	empty := DefaultConfig()
+	empty.EnabledPatterns = nil
+	if d := DiffConfigs(DefaultConfig(), empty); !d.Empty() || d.String() != "no changes" {
+		t.Errorf("Expected no changes, got %q", d)
+	}
+
+	// DisableBuiltins disables every default builtin
+	none := DefaultConfig()
+	none.DisableBuiltins = true
+	if d := DiffConfigs(DefaultConfig(), none); len(d.Disabled) != len(builtinPatterns) {
+		t.Errorf("Expected all %d builtins disabled, got %v", len(builtinPatterns), d.Disabled)
+	}
+}
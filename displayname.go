@@ -0,0 +1,43 @@
+package piiredact
+
+import (
+	"regexp"
+)
+
+// Display names in addresses.
+//
+// Text pasted from email clients carries addresses in the RFC 5322 form
+// "John Smith <john@example.com>". EMAIL redacts the address, but the
+// display name in front of it is almost always the person's name. With
+// Config.RedactDisplayNames the name is redacted as well, under "NAME".
+//
+// A display name is either quoted, as in "\"Smith, John\" <...>", or a run
+// of one to four capitalized words directly before the angle brackets, so
+// "write to <help@example.com>" leaves "write to" alone.
+
+// displayNameRegex matches a display name followed by an address in angle
+// brackets; group 1 is a quoted name and group 2 an unquoted one.
+var displayNameRegex = regexp.MustCompile(`(?:"([^"<>\n]{1,64})"|\b((?:\p{Lu}[\p{L}'.\-]*[ \t]+){0,3}\p{Lu}[\p{L}'.\-]*))[ \t]*<[^<>\s@]+@[^<>\s@]+>`)
+
+// findDisplayNames returns the spans of the display names in text.
+func findDisplayNames(text string) [][]int {
+	var spans [][]int
+	for _, m := range displayNameRegex.FindAllStringSubmatchIndex(text, -1) {
+		switch {
+		case m[2] >= 0:
+			spans = append(spans, []int{m[2], m[3]})
+		case m[4] >= 0:
+			spans = append(spans, []int{m[4], m[5]})
+		}
+	}
+	return spans
+}
+
+// displayNamePattern redacts display names when Config.RedactDisplayNames is set.
+var displayNamePattern = PatternDef{
+	Name:        "NAME",
+	Description: "Display name of an email address",
+	Regex:       displayNameRegex,
+	find:        findDisplayNames,
+	Sensitivity: SensitivityMedium,
+}
@@ -0,0 +1,39 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_RedactDisplayNames tests redacting names in "Name <email>" form
+func TestRedactionEngine_RedactDisplayNames(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"From: John Smith <john@example.com>", "From: [NAME] <[EMAIL]>"},
+		{"To: \"Smith, John\" <john@example.com>", "To: \"[NAME]\" <[EMAIL]>"},
+		{"Cc: Mary-Jane O'Neil<mj@example.org>, Li Wei <li.wei@example.cn>", "Cc: [NAME]<[EMAIL]>, [NAME] <[EMAIL]>"},
+		{"Reply from Ann Lee <ann@example.com>", "Reply from [NAME] <[EMAIL]>"},
+		// Without a display name only the address is redacted
+		{"From: <john@example.com>", "From: <[EMAIL]>"},
+		{"write to <help@example.com> today", "write to <[EMAIL]> today"},
+		{"email john@example.com", "email [EMAIL]"},
+	}
+
+	config := DefaultConfig()
+	config.RedactDisplayNames = true
+	engine := NewRedactionEngine(config)
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Display names are kept by default
+	input := "From: John Smith <john@example.com>"
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "From: John Smith <[EMAIL]>"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
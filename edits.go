@@ -0,0 +1,51 @@
+package piiredact
+
+// Edit is one replacement in a chunk's text, for callers that apply
+// redactions to text they already hold instead of receiving it again.
+//
+// Offset and OriginalLen are in bytes of the original chunk text. Edits of
+// a chunk are sorted and do not overlap, and all offsets refer to the
+// original text, so applying them from last to first (or tracking the
+// change in length while going forward) reproduces the redacted text.
+type Edit struct {
+	Offset      int    `json:"offset"`       // Byte offset of the replaced text
+	OriginalLen int    `json:"original_len"` // Byte length of the replaced text
+	Replacement string `json:"replacement"`  // Text to put in its place
+}
+
+// ProcessEdits redacts chunks like Process but returns, for each chunk in
+// chunks, the edits that turn its text into the redacted text instead of
+// the redacted chunks. Chunks with nothing to redact, including empty
+// chunks whether or not DropEmptyChunks is set, have no edits.
+//
+// Metrics, audit records and errors are as for Process. With InvalidUTF8
+// set to UTF8Sanitize, offsets refer to the sanitized text.
+func (e *RedactionEngine) ProcessEdits(chunks []Chunk) ([][]Edit, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	edits := make([][]Edit, len(chunks))
+	_, err := e.process(chunks, func(i int, r *redaction) {
+		if len(r.matches) == 0 {
+			return
+		}
+		chunkEdits := make([]Edit, len(r.matches))
+		for j, m := range r.matches {
+			chunkEdits[j] = Edit{Offset: m.start, OriginalLen: m.end - m.start, Replacement: m.replacement}
+		}
+		edits[i] = chunkEdits
+	})
+	return edits, err
+}
+
+// ApplyEdits applies the edits of one chunk, as returned by ProcessEdits,
+// to its original text. It panics if an edit lies outside text.
+func ApplyEdits(text string, edits []Edit) string {
+	var out []byte
+	last := 0
+	for _, edit := range edits {
+		out = append(out, text[last:edit.Offset]...)
+		out = append(out, edit.Replacement...)
+		last = edit.Offset + edit.OriginalLen
+	}
+	return string(append(out, text[last:]...))
+}
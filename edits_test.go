@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRedactionEngine_ProcessEdits tests that edits reproduce the redacted text
+func TestRedactionEngine_ProcessEdits(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 401-23-4567, email jane@example.com."},
+		{UUID: "id2", Speaker: "B", Text: "Nothing here"},
+		{UUID: "id3", Speaker: "A", Text: ""},
+		{UUID: "id4", Speaker: "B", Text: "Card 4111 1111 1111 1111 and phone 555-123-4567"},
+	}
+
+	for _, mode := range []RedactionMode{ModeLabel, ModeMask, ModeToken} {
+		for _, drop := range []bool{false, true} {
+			config := DefaultConfig()
+			config.Mode = mode
+			config.DropEmptyChunks = drop
+			config.TokenKey = []byte("edits-test-key")
+
+			edits, err := NewRedactionEngine(config).ProcessEdits(chunks)
+			if err != nil {
+				t.Fatalf("ProcessEdits returned error: %v", err)
+			}
+			if len(edits) != len(chunks) {
+				t.Fatalf("Expected %d edit lists, got %d", len(chunks), len(edits))
+			}
+
+			// Compare with Process on the same chunks, keeping empty ones
+			reference := config
+			reference.DropEmptyChunks = false
+			redacted, _ := NewRedactionEngine(reference).Process(chunks)
+			for i, c := range chunks {
+				if isEmptyChunk(c) {
+					if edits[i] != nil {
+						t.Errorf("Expected no edits for empty chunk %s, got %v", c.UUID, edits[i])
+					}
+					continue
+				}
+				if got := ApplyEdits(c.Text, edits[i]); got != redacted[i].Text {
+					t.Errorf("Mode %d, chunk %s: Expected: %s\nGot: %s", mode, c.UUID, redacted[i].Text, got)
+				}
+			}
+		}
+	}
+
+	// Offsets are in the original text
+	edits, _ := NewRedactionEngine(DefaultConfig()).ProcessEdits(chunks[:1])
+	expected := []Edit{
+		{Offset: 4, OriginalLen: 11, Replacement: "[SSN]"},
+		{Offset: 23, OriginalLen: 16, Replacement: "[EMAIL]"},
+	}
+	if !reflect.DeepEqual(edits[0], expected) {
+		t.Errorf("ProcessEdits() = %+v, expected %+v", edits[0], expected)
+	}
+}
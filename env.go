@@ -0,0 +1,90 @@
+package piiredact
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvPatterns    = "PIIREDACT_PATTERNS"    // Comma-separated pattern names, e.g. "SSN,EMAIL,IMEI"
+	EnvFormat      = "PIIREDACT_FORMAT"      // RedactionFormat, with one %s for the label, e.g. "<%s>"
+	EnvConcurrency = "PIIREDACT_CONCURRENCY" // MaxConcurrency, a positive integer
+	EnvMode        = "PIIREDACT_MODE"        // Mode: "label", "mask", "token" or "fpe"
+)
+
+// modeNames maps the PIIREDACT_MODE values to modes.
+var modeNames = map[string]RedactionMode{
+	"label": ModeLabel,
+	"mask":  ModeMask,
+	"token": ModeToken,
+	"fpe":   ModeFPE,
+}
+
+// ConfigFromEnv builds a Config from environment variables, for
+// containerized deployments configured twelve-factor style. It starts from
+// DefaultConfig and applies each variable that is set and not empty:
+//
+//   - PIIREDACT_PATTERNS sets EnabledPatterns to the listed builtin
+//     patterns, optional ones included, so "SSN,EMAIL,IMEI" enables those
+//     three alone. Names are case-insensitive.
+//   - PIIREDACT_FORMAT sets RedactionFormat, which must contain exactly
+//     one %s verb and no other.
+//   - PIIREDACT_CONCURRENCY sets MaxConcurrency to a positive integer.
+//   - PIIREDACT_MODE sets Mode to "label", "mask", "token" or "fpe".
+//
+// Keys such as FPEKey and TokenKey are never read from the environment;
+// set them on the returned Config from a secret store. It returns an error
+// naming the variable if any value is malformed.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if v := strings.TrimSpace(os.Getenv(EnvPatterns)); v != "" {
+		known := make(map[string]bool)
+		for _, p := range slices.Concat(builtinPatterns, optionalPatterns) {
+			known[p.Name] = true
+		}
+		config.EnabledPatterns = make(map[string]bool)
+		for _, name := range strings.Split(v, ",") {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				return Config{}, fmt.Errorf("piiredact: %s: %w: %s", EnvPatterns, ErrUnknownPattern, name)
+			}
+			config.EnabledPatterns[name] = true
+		}
+		if len(config.EnabledPatterns) == 0 {
+			return Config{}, fmt.Errorf("piiredact: %s: no pattern names in %q", EnvPatterns, v)
+		}
+	}
+
+	if v := os.Getenv(EnvFormat); v != "" {
+		if strings.Count(v, "%s") != 1 || strings.Count(strings.ReplaceAll(v, "%%", ""), "%") != 1 {
+			return Config{}, fmt.Errorf("piiredact: %s: %q must contain exactly one %%s and no other verbs", EnvFormat, v)
+		}
+		config.RedactionFormat = v
+	}
+
+	if v := strings.TrimSpace(os.Getenv(EnvConcurrency)); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("piiredact: %s: %q is not a positive integer", EnvConcurrency, v)
+		}
+		config.MaxConcurrency = n
+	}
+
+	if v := strings.TrimSpace(os.Getenv(EnvMode)); v != "" {
+		mode, ok := modeNames[strings.ToLower(v)]
+		if !ok {
+			return Config{}, fmt.Errorf("piiredact: %s: unknown mode %q (want label, mask, token or fpe)", EnvMode, v)
+		}
+		config.Mode = mode
+	}
+
+	return config, nil
+}
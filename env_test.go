@@ -0,0 +1,84 @@
+package piiredact
+
+import (
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// TestConfigFromEnv tests building a Config from environment variables
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvPatterns, "ssn, Email,IMEI,")
+	t.Setenv(EnvFormat, "<%s>")
+	t.Setenv(EnvConcurrency, "4")
+	t.Setenv(EnvMode, "Mask")
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+	if want := map[string]bool{"SSN": true, "EMAIL": true, "IMEI": true}; !maps.Equal(config.EnabledPatterns, want) {
+		t.Errorf("Expected patterns %v, got %v", want, config.EnabledPatterns)
+	}
+	if config.RedactionFormat != "<%s>" || config.MaxConcurrency != 4 || config.Mode != ModeMask {
+		t.Errorf("Unexpected config: format %q, concurrency %d, mode %d", config.RedactionFormat, config.MaxConcurrency, config.Mode)
+	}
+
+	// The config works end to end
+	config.Mode = ModeLabel
+	result, _ := NewRedactionEngine(config).Process([]Chunk{{UUID: "u", Speaker: "A", Text: "SSN 123-45-6789, call 404-555-1212"}})
+	if expected := "SSN <SSN>, call 404-555-1212"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestConfigFromEnv_Defaults tests that unset variables keep the defaults
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	for _, name := range []string{EnvPatterns, EnvFormat, EnvConcurrency, EnvMode} {
+		t.Setenv(name, "")
+	}
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+	defaults := DefaultConfig()
+	if !maps.Equal(config.EnabledPatterns, defaults.EnabledPatterns) || config.RedactionFormat != defaults.RedactionFormat ||
+		config.MaxConcurrency != defaults.MaxConcurrency || config.Mode != defaults.Mode {
+		t.Errorf("Expected the defaults, got %+v", config)
+	}
+}
+
+// TestConfigFromEnv_Errors tests rejecting malformed values
+func TestConfigFromEnv_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{EnvPatterns, "SSN,NOPE"},
+		{EnvPatterns, " , "},
+		{EnvFormat, "no verb"},
+		{EnvFormat, "%s and %s"},
+		{EnvFormat, "%d"},
+		{EnvFormat, "%s at 100%"},
+		{EnvConcurrency, "zero"},
+		{EnvConcurrency, "-2"},
+		{EnvMode, "blackout"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+"="+tc.value, func(t *testing.T) {
+			t.Setenv(tc.name, tc.value)
+			_, err := ConfigFromEnv()
+			if err == nil || !strings.Contains(err.Error(), tc.name) {
+				t.Errorf("Expected an error naming %s, got %v", tc.name, err)
+			}
+		})
+	}
+
+	t.Setenv(EnvPatterns, "SSN,NOPE")
+	if _, err := ConfigFromEnv(); !errors.Is(err, ErrUnknownPattern) {
+		t.Errorf("Expected ErrUnknownPattern, got %v", err)
+	}
+}
@@ -13,10 +13,10 @@ import (
 func ExampleBasicUsage() {
 	// Create sample chunks with PII
 	chunks := []Chunk{
-		{"018f8f54-5b49-7cc5-9c3f-99b00a5f1cde", "A", "My social security is 401-23-4567"},
-		{"018f8f54-5b49-7cc5-9c3f-99b00a5f1cdf", "B", "wait—what?"},
-		{"018f8f54-5b49-7cc5-9c3f-99b00a5f1ce0", "A", "my card is 4111 1111 1111 1111"},
-		{"018f8f54-5b49-7cc5-9c3f-99b00a5f1ce1", "A", "call me at 404-555-1212"},
+		{UUID: "018f8f54-5b49-7cc5-9c3f-99b00a5f1cde", Speaker: "A", Text: "My social security is 401-23-4567"},
+		{UUID: "018f8f54-5b49-7cc5-9c3f-99b00a5f1cdf", Speaker: "B", Text: "wait—what?"},
+		{UUID: "018f8f54-5b49-7cc5-9c3f-99b00a5f1ce0", Speaker: "A", Text: "my card is 4111 1111 1111 1111"},
+		{UUID: "018f8f54-5b49-7cc5-9c3f-99b00a5f1ce1", Speaker: "A", Text: "call me at 404-555-1212"},
 	}
 
 	// Create a redaction engine with default configuration
@@ -97,8 +97,8 @@ func ExampleCustomConfiguration() {
 
 	// Process sample chunks
 	chunks := []Chunk{
-		{"id1", "A", "My employee ID is EMP-123456 and my email is user@example.com"},
-		{"id2", "B", "My credit card is 4111 1111 1111 1111"}, // CC pattern disabled
+		{UUID: "id1", Speaker: "A", Text: "My employee ID is EMP-123456 and my email is user@example.com"},
+		{UUID: "id2", Speaker: "B", Text: "My credit card is 4111 1111 1111 1111"}, // CC pattern disabled
 	}
 
 	redacted, _ := engine.Process(chunks)
@@ -131,9 +131,9 @@ func ExampleMetrics() {
 
 	// Process a batch of chunks with various PII
 	chunks := []Chunk{
-		{"id1", "A", "SSN: 123-45-6789, Phone: 555-123-4567"},
-		{"id2", "B", "Email: user@example.com"},
-		{"id3", "A", "Credit card: 4111 1111 1111 1111"},
+		{UUID: "id1", Speaker: "A", Text: "SSN: 123-45-6789, Phone: 555-123-4567"},
+		{UUID: "id2", Speaker: "B", Text: "Email: user@example.com"},
+		{UUID: "id3", Speaker: "A", Text: "Credit card: 4111 1111 1111 1111"},
 	}
 
 	engine.Process(chunks)
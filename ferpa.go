@@ -0,0 +1,65 @@
+package piiredact
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// FERPA education records.
+//
+// Student records protected by FERPA carry institution-specific student
+// IDs and grades. Neither is safe to detect by default: ID formats differ
+// between institutions, and grades such as "B+" or "3.8" are only PII in
+// context. FERPAPatterns builds both for Config.CustomPatterns, so the
+// whole set is enabled in one step:
+//
+//	ferpa, err := piiredact.FERPAPatterns("S########", "STU-######")
+//	config.CustomPatterns = append(config.CustomPatterns, ferpa...)
+
+// studentIDPriority lets STUDENT_ID win over DL and other generic patterns
+// that match the same characters.
+const studentIDPriority = 1
+
+// gradeRegex matches a GPA such as "3.85" or a letter grade such as "B+".
+var gradeRegex = regexp.MustCompile(`\b(?:[0-4]\.[0-9]{1,2}\b|[A-DF](?:[+-]|\b))`)
+
+// gradeCues are the lowercase words that introduce a grade or GPA.
+var gradeCues = []string{"gpa", "grade point average", "grade", "final grade", "graded", "scored", "received", "got"}
+
+// gradeConnectors may separate a grade cue from the grade.
+var gradeConnectors = []string{":", "=", "is", "was", "of", "an", "a"}
+
+// afterGradeCue reports whether the match at start directly follows one of
+// gradeCues.
+func afterGradeCue(text string, start, end int) bool {
+	_, _, ok := cueBefore(text, start, gradeCues, gradeConnectors)
+	return ok
+}
+
+// FERPAPatterns returns the patterns for FERPA-protected education data: a
+// STUDENT_ID pattern for each of the institution's ID templates (see
+// TemplatePattern for the syntax), which wins overlaps with builtins such
+// as DL, and a GRADE pattern for grades and GPAs that directly follow a cue
+// such as "GPA of" or "got a". It returns an error if a template is
+// invalid.
+func FERPAPatterns(studentIDTemplates ...string) ([]PatternDef, error) {
+	patterns := make([]PatternDef, 0, len(studentIDTemplates)+1)
+	for _, template := range studentIDTemplates {
+		p, err := TemplatePattern("STUDENT_ID", template)
+		if err != nil {
+			return nil, fmt.Errorf("piiredact: student ID: %w", err)
+		}
+		p.Priority = studentIDPriority
+		p.Sensitivity = SensitivityHigh
+		p.Description = "Student ID"
+		patterns = append(patterns, p)
+	}
+
+	return append(patterns, PatternDef{
+		Name:            "GRADE",
+		Description:     "Student grade",
+		Regex:           gradeRegex,
+		ContextValidate: afterGradeCue,
+		Sensitivity:     SensitivityMedium,
+	}), nil
+}
@@ -0,0 +1,52 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestFERPAPatterns tests redacting template-built student IDs and cue-gated grades
+func TestFERPAPatterns(t *testing.T) {
+	ferpa, err := FERPAPatterns("S########", "STU-YYYY-####")
+	if err != nil {
+		t.Fatalf("FERPAPatterns returned error: %v", err)
+	}
+	config := DefaultConfig()
+	config.CustomPatterns = ferpa
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Student S12345678 enrolled", "Student [STUDENT_ID] enrolled"},
+		{"ID STU-2023-0042 on file", "ID [STUDENT_ID] on file"},
+		{"Her GPA is 3.85 this term", "Her GPA is [GRADE] this term"},
+		{"GPA: 4.0", "GPA: [GRADE]"},
+		{"She got a B+ in chemistry", "She got a [GRADE] in chemistry"},
+		{"final grade was C-.", "final grade was [GRADE]."},
+		{"He received an A", "He received an [GRADE]"},
+		// IDs that do not fit a template, and grades without a cue, are kept
+		{"ID STU-1850-0042", "ID STU-1850-0042"},
+		{"Version 3.85 shipped", "Version 3.85 shipped"},
+		{"Plan A is fine", "Plan A is fine"},
+		{"grade 10 student", "grade 10 student"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without FERPAPatterns nothing education-specific is redacted
+	input := "Student STU-2023-0042 has a GPA of 3.85"
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if result[0].Text != input {
+		t.Errorf("Expected FERPA patterns to be opt-in, got: %s", result[0].Text)
+	}
+
+	if _, err := FERPAPatterns("S###\\"); err == nil {
+		t.Error("Expected an error for an invalid template")
+	}
+}
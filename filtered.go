@@ -0,0 +1,49 @@
+package piiredact
+
+// ProcessFiltered redacts the chunks for which keep returns true and
+// passes the others through unchanged, preserving the order of chunks.
+//
+// Selected chunks are handled exactly as by Process, including metrics,
+// DropEmptyChunks and error reporting; chunks that are not selected are
+// neither counted nor dropped. keep is called once per chunk, in order, on
+// the calling goroutine.
+func (e *RedactionEngine) ProcessFiltered(chunks []Chunk, keep func(Chunk) bool) ([]Chunk, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	const (
+		passThrough = iota
+		redact
+		drop
+	)
+
+	action := make([]int, len(chunks))
+	var input []Chunk
+	for i, c := range chunks {
+		switch {
+		case !keep(c):
+			action[i] = passThrough
+		case e.config.DropEmptyChunks && isEmptyChunk(c):
+			// Drop empty chunks here so Process returns one result per input
+			e.countSkippedEmpty()
+			action[i] = drop
+		default:
+			action[i] = redact
+			input = append(input, c)
+		}
+	}
+
+	redacted, err := e.process(input, nil)
+
+	result := make([]Chunk, 0, len(chunks))
+	next := 0
+	for i, c := range chunks {
+		switch action[i] {
+		case passThrough:
+			result = append(result, c)
+		case redact:
+			result = append(result, redacted[next])
+			next++
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,45 @@
+package piiredact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_ProcessFiltered tests redacting only chunks selected by a predicate
+func TestRedactionEngine_ProcessFiltered(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "cust-1", Speaker: "A", Text: "My SSN is 401-23-4567"},
+		{UUID: "agent-1", Speaker: "B", Text: "Reach me at 555-123-4567"},
+		{UUID: "cust-2", Speaker: "A", Text: "  "},
+		{UUID: "agent-2", Speaker: "B", Text: ""},
+		{UUID: "cust-3", Speaker: "A", Text: "Email jane@example.com"},
+	}
+	customer := func(c Chunk) bool { return strings.HasPrefix(c.UUID, "cust-") }
+
+	config := DefaultConfig()
+	config.DropEmptyChunks = true
+	engine := NewRedactionEngine(config)
+
+	result, err := engine.ProcessFiltered(chunks, customer)
+	if err != nil {
+		t.Fatalf("ProcessFiltered returned error: %v", err)
+	}
+
+	// The empty customer chunk is dropped; the empty agent chunk is passed through
+	expected := []Chunk{
+		{UUID: "cust-1", Speaker: "A", Text: "My SSN is [SSN]"},
+		{UUID: "agent-1", Speaker: "B", Text: "Reach me at 555-123-4567"},
+		{UUID: "agent-2", Speaker: "B", Text: ""},
+		{UUID: "cust-3", Speaker: "A", Text: "Email [EMAIL]"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProcessFiltered() = %v, expected %v", result, expected)
+	}
+
+	metrics := engine.GetMetrics()
+	if metrics.ProcessedChunks != 2 || metrics.SkippedEmpty != 1 || metrics.RedactedItems["PHONE"] != 0 {
+		t.Errorf("Unexpected metrics: processed %d, skipped %d, phones %d",
+			metrics.ProcessedChunks, metrics.SkippedEmpty, metrics.RedactedItems["PHONE"])
+	}
+}
@@ -0,0 +1,99 @@
+package piiredact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// FixedWidthField is a column span of a fixed-width record.
+type FixedWidthField struct {
+	Start int // First column of the field, counting characters from 0
+	End   int // Column just past the field
+}
+
+// FixedWidthOptions controls RedactFixedWidth.
+type FixedWidthOptions struct {
+	Mask bool // Always mask values in place instead of writing labels that fit
+}
+
+// RedactFixedWidth redacts the given column spans of each line of a
+// fixed-width file, such as a mainframe extract, leaving every other
+// column and the length of every line unchanged.
+//
+// Each field is redacted as its own chunk. If the redacted field is no
+// wider than the field it is padded with trailing spaces; if a label would
+// not fit, or opts.Mask is set, each detected value is masked in place
+// instead, as in ModeMask, which keeps its width. Columns count characters
+// rather than bytes, fields that extend past the end of a short line are
+// cut at the line's end, and line endings are preserved.
+func (e *RedactionEngine) RedactFixedWidth(r io.Reader, w io.Writer, fields []FixedWidthField, opts FixedWidthOptions) error {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+
+	for n := 1; ; n++ {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			body, ending := splitLineEnding(line)
+			e.configMu.RLock()
+			body = e.redactFields(body, fields, opts, fmt.Sprintf("line-%d", n))
+			e.configMu.RUnlock()
+			if _, werr := bw.WriteString(body + ending); werr != nil {
+				return werr
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// redactFields redacts the fields of one fixed-width record.
+func (e *RedactionEngine) redactFields(line string, fields []FixedWidthField, opts FixedWidthOptions, uuid string) string {
+	runes := []rune(line)
+	for _, f := range fields {
+		start, end := max(f.Start, 0), min(f.End, len(runes))
+		if start >= end {
+			continue
+		}
+		text := string(runes[start:end])
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		r := e.newRedaction()
+		redacted := e.redactChunkWith(Chunk{UUID: uuid, Text: text}, r).Text
+		if len(r.matches) > 0 {
+			width := end - start
+			if n := utf8.RuneCountInString(redacted); !opts.Mask && n <= width {
+				redacted += strings.Repeat(" ", width-n)
+			} else {
+				redacted = e.maskMatches(text, r.matches)
+			}
+			copy(runes[start:end], []rune(redacted))
+		}
+		r.release()
+	}
+	return string(runes)
+}
+
+// maskMatches masks each match in text in place, keeping its length.
+func (e *RedactionEngine) maskMatches(text string, matches []match) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m.start])
+		b.WriteString(e.mask(text[m.start:m.end]))
+		last = m.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package piiredact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestRedactFixedWidth tests redacting column spans of fixed-width records
+func TestRedactFixedWidth(t *testing.T) {
+	// Columns: name 0-10, SSN 10-21, phone 21-33, note 33-end
+	input := "JANE DOE  123-45-6789555-123-4567Call back\r\n" +
+		"JOHN ROE  401-23-4567404-555-1212 email jane@example.com\n" +
+		"SHORT\n" +
+		"ANNA LEE  not given  none"
+	fields := []FixedWidthField{{10, 21}, {21, 33}, {33, 60}}
+
+	testCases := []struct {
+		name     string
+		opts     FixedWidthOptions
+		expected string
+	}{
+		{"labels", FixedWidthOptions{}, "JANE DOE  [SSN]      [PHONE]     Call back\r\n" +
+			"JOHN ROE  [SSN]      [PHONE]      email [EMAIL]         \n" +
+			"SHORT\n" +
+			"ANNA LEE  not given  none"},
+		{"mask", FixedWidthOptions{Mask: true}, "JANE DOE  XXX-XX-6789XXX-XXX-4567Call back\r\n" +
+			"JOHN ROE  XXX-XX-4567XXX-XXX-1212 email XXXX@XXXXXXe.com\n" +
+			"SHORT\n" +
+			"ANNA LEE  not given  none"},
+	}
+
+	engine := NewRedactionEngine(DefaultConfig())
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		if err := engine.RedactFixedWidth(strings.NewReader(input), &out, fields, tc.opts); err != nil {
+			t.Fatalf("%s: RedactFixedWidth returned error: %v", tc.name, err)
+		}
+		if out.String() != tc.expected {
+			t.Errorf("%s: Expected:\n%q\nGot:\n%q", tc.name, tc.expected, out.String())
+		}
+
+		// Every line keeps its length
+		inLines, outLines := strings.Split(input, "\n"), strings.Split(out.String(), "\n")
+		for i := range inLines {
+			if utf8.RuneCountInString(inLines[i]) != utf8.RuneCountInString(outLines[i]) {
+				t.Errorf("%s: line %d changed length: %q -> %q", tc.name, i+1, inLines[i], outLines[i])
+			}
+		}
+	}
+}
+
+// TestRedactFixedWidth_LongLabel tests masking when a label is wider than its field
+func TestRedactFixedWidth_LongLabel(t *testing.T) {
+	config := DefaultConfig()
+	config.RedactionFormat = "<<REDACTED %s>>"
+	engine := NewRedactionEngine(config)
+
+	var out bytes.Buffer
+	input := "JANE DOE  123-45-6789END\n"
+	if err := engine.RedactFixedWidth(strings.NewReader(input), &out, []FixedWidthField{{10, 21}}, FixedWidthOptions{}); err != nil {
+		t.Fatalf("RedactFixedWidth returned error: %v", err)
+	}
+	if expected := "JANE DOE  XXX-XX-6789END\n"; out.String() != expected {
+		t.Errorf("Expected: %q\nGot: %q", expected, out.String())
+	}
+}
@@ -0,0 +1,256 @@
+package piiredact
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Format-preserving encryption (FPE) of SSNs.
+//
+// In ModeFPE the engine replaces each SSN with another SSN-shaped value
+// using FF1 (NIST SP 800-38G) over the decimal digits, keyed with AES. The
+// nine digits are encrypted as one numeral string; separators such as the
+// dashes in "123-45-6789" are kept in place. Because FF1 on its own can
+// produce digit strings that validateSSN rejects (area 000, 666 or 9xx, for
+// example), encryption cycle-walks: it re-encrypts until the output is a
+// valid SSN, and decryption walks back the same way. The result is therefore
+// always a syntactically valid SSN that downstream validators accept.
+//
+// Key handling: Config.FPEKey must be a 16, 24 or 32 byte AES key. The
+// mapping is deterministic, so the same SSN always encrypts to the same
+// value under the same key; this keeps joins working but also means
+// repeated values can be correlated. Anyone holding the key can reverse
+// every token with DecryptSSN, so store it in a secrets manager, never
+// alongside the redacted data, and treat key rotation as a re-encryption of
+// all stored values.
+
+// ff1Rounds is the number of Feistel rounds mandated by FF1.
+const ff1Rounds = 10
+
+// ErrInvalidFPEKey is returned when an FPE key is not a valid AES key.
+var ErrInvalidFPEKey = errors.New("piiredact: FPE key must be 16, 24 or 32 bytes")
+
+// ff1 implements the FF1 mode of format-preserving encryption for radix 10.
+type ff1 struct {
+	block cipher.Block
+	tweak []byte
+}
+
+// newFF1 creates an FF1 cipher for decimal numeral strings.
+func newFF1(key, tweak []byte) (*ff1, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidFPEKey
+	}
+	return &ff1{block: block, tweak: tweak}, nil
+}
+
+// encrypt enciphers a string of decimal digits of length 6 or more.
+func (f *ff1) encrypt(digits string) (string, error) {
+	return f.cipher(digits, true)
+}
+
+// decrypt deciphers a string produced by encrypt.
+func (f *ff1) decrypt(digits string) (string, error) {
+	return f.cipher(digits, false)
+}
+
+// cipher runs the FF1 Feistel network in either direction.
+func (f *ff1) cipher(digits string, encrypt bool) (string, error) {
+	const radix = 10
+	n := len(digits)
+	if n < 6 {
+		return "", fmt.Errorf("piiredact: FF1 input must have at least 6 digits, got %d", n)
+	}
+	for i := 0; i < n; i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return "", fmt.Errorf("piiredact: FF1 input must be decimal digits")
+		}
+	}
+
+	u := n / 2
+	v := n - u
+	a, b := digits[:u], digits[u:]
+
+	// b: bytes needed to hold radix^v; d: bytes of PRF output used per round
+	bLen := (bitLen(radix, v) + 7) / 8
+	d := 4*((bLen+3)/4) + 4
+
+	t := len(f.tweak)
+	p := []byte{1, 2, 1, 0, 0, radix, 10, byte(u % 256),
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t)}
+
+	radixBig := big.NewInt(radix)
+	modU := new(big.Int).Exp(radixBig, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(radixBig, big.NewInt(int64(v)), nil)
+
+	for r := 0; r < ff1Rounds; r++ {
+		i := r
+		if !encrypt {
+			i = ff1Rounds - 1 - r
+		}
+
+		// Q = T || 0^((-t-b-1) mod 16) || [i]^1 || [NUM(B or A)]^b
+		src := b
+		if !encrypt {
+			src = a
+		}
+		pad := ((-t-bLen-1)%16 + 16) % 16
+		q := make([]byte, 0, t+pad+1+bLen)
+		q = append(q, f.tweak...)
+		q = append(q, make([]byte, pad)...)
+		q = append(q, byte(i))
+		q = append(q, bigBytes(numeral(src), bLen)...)
+
+		y := new(big.Int).SetBytes(f.expand(f.prf(append(append([]byte{}, p...), q...)), d))
+
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+
+		if encrypt {
+			c := new(big.Int).Add(numeral(a), y)
+			c.Mod(c, mod)
+			a, b = b, digitString(c, m)
+		} else {
+			c := new(big.Int).Sub(numeral(b), y)
+			c.Mod(c, mod)
+			a, b = digitString(c, m), a
+		}
+	}
+
+	return a + b, nil
+}
+
+// prf is the AES CBC-MAC with a zero IV over data, whose length is a
+// multiple of the block size.
+func (f *ff1) prf(data []byte) []byte {
+	y := make([]byte, aes.BlockSize)
+	for i := 0; i < len(data); i += aes.BlockSize {
+		for j := 0; j < aes.BlockSize; j++ {
+			y[j] ^= data[i+j]
+		}
+		f.block.Encrypt(y, y)
+	}
+	return y
+}
+
+// expand stretches the PRF output R to d bytes as FF1 specifies.
+func (f *ff1) expand(r []byte, d int) []byte {
+	s := append([]byte{}, r...)
+	for j := 1; len(s) < d; j++ {
+		block := make([]byte, aes.BlockSize)
+		copy(block, r)
+		ctr := bigBytes(big.NewInt(int64(j)), aes.BlockSize)
+		for k := range block {
+			block[k] ^= ctr[k]
+		}
+		f.block.Encrypt(block, block)
+		s = append(s, block...)
+	}
+	return s[:d]
+}
+
+// bitLen returns ceil(m * log2(radix)) for radix 10 by counting bits of radix^m.
+func bitLen(radix, m int) int {
+	max := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(m)), nil)
+	max.Sub(max, big.NewInt(1))
+	return max.BitLen()
+}
+
+// numeral interprets a string of decimal digits as an integer.
+func numeral(digits string) *big.Int {
+	n, _ := new(big.Int).SetString(digits, 10)
+	return n
+}
+
+// digitString renders n as exactly m decimal digits.
+func digitString(n *big.Int, m int) string {
+	s := n.String()
+	if len(s) < m {
+		s = strings.Repeat("0", m-len(s)) + s
+	}
+	return s
+}
+
+// bigBytes renders n as a big-endian byte string of exactly size bytes.
+func bigBytes(n *big.Int, size int) []byte {
+	out := make([]byte, size)
+	return n.FillBytes(out)
+}
+
+// splitDigits separates the digits of value from its layout so the
+// encrypted digits can be written back around the same separators.
+func splitDigits(value string) string {
+	var digits strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] >= '0' && value[i] <= '9' {
+			digits.WriteByte(value[i])
+		}
+	}
+	return digits.String()
+}
+
+// mergeDigits writes digits back into the digit positions of layout.
+func mergeDigits(layout, digits string) string {
+	out := []byte(layout)
+	j := 0
+	for i := range out {
+		if out[i] >= '0' && out[i] <= '9' {
+			out[i] = digits[j]
+			j++
+		}
+	}
+	return string(out)
+}
+
+// encryptSSN format-preservingly encrypts an SSN, cycle-walking until the
+// result is itself a valid SSN.
+func (f *ff1) encryptSSN(ssn string) (string, error) {
+	digits := splitDigits(ssn)
+	if len(digits) != 9 {
+		return "", fmt.Errorf("piiredact: SSN must have 9 digits")
+	}
+	for {
+		var err error
+		if digits, err = f.encrypt(digits); err != nil {
+			return "", err
+		}
+		if validateSSN(digits) {
+			return mergeDigits(ssn, digits), nil
+		}
+	}
+}
+
+// decryptSSN reverses encryptSSN.
+func (f *ff1) decryptSSN(ssn string) (string, error) {
+	digits := splitDigits(ssn)
+	if len(digits) != 9 {
+		return "", fmt.Errorf("piiredact: SSN must have 9 digits")
+	}
+	for {
+		var err error
+		if digits, err = f.decrypt(digits); err != nil {
+			return "", err
+		}
+		if validateSSN(digits) {
+			return mergeDigits(ssn, digits), nil
+		}
+	}
+}
+
+// DecryptSSN recovers the original SSN from a value produced in ModeFPE
+// with the same key. Separators in the input are preserved.
+func DecryptSSN(key []byte, encrypted string) (string, error) {
+	f, err := newFF1(key, nil)
+	if err != nil {
+		return "", err
+	}
+	return f.decryptSSN(encrypted)
+}
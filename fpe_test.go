@@ -0,0 +1,97 @@
+package piiredact
+
+import (
+	"encoding/hex"
+	"regexp"
+	"testing"
+)
+
+// TestFF1_NISTVectors tests the FF1 implementation against NIST SP 800-38G samples
+func TestFF1_NISTVectors(t *testing.T) {
+	key, _ := hex.DecodeString("2B7E151628AED2A6ABF7158809CF4F3C")
+
+	tests := []struct {
+		tweak      string
+		plaintext  string
+		ciphertext string
+	}{
+		{"", "0123456789", "2433477484"},
+		{"39383736353433323130", "0123456789", "6124200773"},
+	}
+
+	for _, tt := range tests {
+		tweak, _ := hex.DecodeString(tt.tweak)
+		f, err := newFF1(key, tweak)
+		if err != nil {
+			t.Fatalf("newFF1 returned error: %v", err)
+		}
+
+		got, err := f.encrypt(tt.plaintext)
+		if err != nil || got != tt.ciphertext {
+			t.Errorf("FF1 encrypt(%s) with tweak %q:\nExpected: %s\nGot: %s (err %v)",
+				tt.plaintext, tt.tweak, tt.ciphertext, got, err)
+		}
+
+		back, err := f.decrypt(tt.ciphertext)
+		if err != nil || back != tt.plaintext {
+			t.Errorf("FF1 decrypt(%s):\nExpected: %s\nGot: %s (err %v)",
+				tt.ciphertext, tt.plaintext, back, err)
+		}
+	}
+}
+
+// TestRedactionEngine_FPE tests that SSNs are replaced with reversible, SSN-shaped values
+func TestRedactionEngine_FPE(t *testing.T) {
+	key, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	config := DefaultConfig()
+	config.Mode = ModeFPE
+	config.FPEKey = key
+	engine := NewRedactionEngine(config)
+
+	ssnShape := regexp.MustCompile(`^My SSN is (\d{3}-\d{2}-\d{4}), call [[]PHONE[]]$`)
+
+	for _, ssn := range []string{"123-45-6789", "401-23-4567", "772-10-0001"} {
+		result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "My SSN is " + ssn + ", call 555-123-4567"}})
+
+		parts := ssnShape.FindStringSubmatch(result[0].Text)
+		if parts == nil {
+			t.Fatalf("FPE output has wrong shape: %q", result[0].Text)
+		}
+		encrypted := parts[1]
+
+		if encrypted == ssn {
+			t.Errorf("FPE left SSN %s unchanged", ssn)
+		}
+		if !validateSSN(encrypted) {
+			t.Errorf("FPE output %s is not a valid SSN", encrypted)
+		}
+
+		// The same SSN always encrypts to the same value
+		again, _ := engine.Process([]Chunk{{UUID: "id2", Speaker: "A", Text: "My SSN is " + ssn + ", call 555-123-4567"}})
+		if again[0].Text != result[0].Text {
+			t.Errorf("FPE is not deterministic: %q vs %q", result[0].Text, again[0].Text)
+		}
+
+		decrypted, err := DecryptSSN(key, encrypted)
+		if err != nil || decrypted != ssn {
+			t.Errorf("DecryptSSN(%s):\nExpected: %s\nGot: %s (err %v)", encrypted, ssn, decrypted, err)
+		}
+	}
+}
+
+// TestRedactionEngine_FPEInvalidKey tests that a bad key falls back to labels
+func TestRedactionEngine_FPEInvalidKey(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = ModeFPE
+	config.FPEKey = []byte("short")
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789"}})
+	if result[0].Text != "My SSN is [SSN]" {
+		t.Errorf("Expected label fallback, got %q", result[0].Text)
+	}
+
+	if _, err := DecryptSSN([]byte("short"), "123-45-6789"); err != ErrInvalidFPEKey {
+		t.Errorf("Expected ErrInvalidFPEKey, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package piiredact
+
+import (
+	"regexp"
+)
+
+// Gift card and coupon codes.
+//
+// Codes such as "X7KQ-9MBD-2PLT" look like any other dash-separated
+// identifier, so the GIFTCARD pattern matches the shape only and relies on
+// its ContextValidate: a code is redacted only when it directly follows a
+// cue such as "gift card" or "code is".
+
+// giftCardRegex matches three to five groups of four or five letters or
+// digits separated by dashes.
+var giftCardRegex = regexp.MustCompile(`\b[A-Za-z0-9]{4,5}(?:-[A-Za-z0-9]{4,5}){2,4}\b`)
+
+// giftCardCues are the lowercase words that introduce a gift card or
+// coupon code. "code" also covers "claim code" and "promo code".
+var giftCardCues = []string{"gift card", "giftcard", "gift certificate", "voucher", "coupon", "code"}
+
+// giftCardConnectors may separate a gift card cue from the code. Longer
+// connectors come first, since the first that matches is removed.
+var giftCardConnectors = []string{"number is", "number:", "number", "is", ":", "#"}
+
+// afterGiftCardCue reports whether the match at start directly follows one
+// of giftCardCues.
+func afterGiftCardCue(text string, start, end int) bool {
+	_, _, ok := cueBefore(text, start, giftCardCues, giftCardConnectors)
+	return ok
+}
@@ -0,0 +1,44 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_GiftCard tests redacting gift card codes after cue words
+func TestRedactionEngine_GiftCard(t *testing.T) {
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"GIFTCARD": true}
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"the gift card is X7KQ-9MBD-2PLT, thanks", "the gift card is [GIFTCARD], thanks"},
+		{"Gift card number: AB12C-3DE45-FG678", "Gift card number: [GIFTCARD]"},
+		{"my code is 4RTY-8UIO-2WER-6ZXC", "my code is [GIFTCARD]"},
+		{"promo code: SAVE-2024-SPRG", "promo code: [GIFTCARD]"},
+		{"voucher #ABCD-EFGH-1234", "voucher #[GIFTCARD]"},
+		// Bare codes and other dashed values are left alone
+		{"order X7KQ-9MBD-2PLT shipped", "order X7KQ-9MBD-2PLT shipped"},
+		{"see ticket ABCD-1234-EFGH", "see ticket ABCD-1234-EFGH"},
+		{"the gift card arrived and X7KQ-9MBD-2PLT was printed on it", "the gift card arrived and X7KQ-9MBD-2PLT was printed on it"},
+		{"barcode X7KQ-9MBD-2PLT", "barcode X7KQ-9MBD-2PLT"},
+		// Codes need at least three groups
+		{"code is AB12-CD34", "code is AB12-CD34"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// The pattern is disabled by default
+	input := "the gift card is X7KQ-9MBD-2PLT"
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if result[0].Text != input {
+		t.Errorf("Expected %q unchanged, got %q", input, result[0].Text)
+	}
+}
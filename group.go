@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"strings"
+)
+
+// Pattern groups.
+//
+// Some PII is made of parts that separate patterns find, such as a street
+// line and a city line, or a first and last name. Tagging those patterns
+// with the same PatternDef.GroupID makes adjacent matches redact as one
+// unit: a run of group matches separated by at most Config.GroupWindow
+// bytes of whitespace and punctuation becomes a single redaction labelled
+// with the GroupID, e.g. "[ADDRESS]", that also covers the text between
+// them. A group match with no neighbour keeps its own pattern's label.
+
+// defaultGroupWindow is used when Config.GroupWindow is not set.
+const defaultGroupWindow = 16
+
+// mergeGroups merges runs of adjacent matches that share a GroupID. The
+// matches must be sorted and disjoint, as resolveOverlaps leaves them.
+func (e *RedactionEngine) mergeGroups(text string, matches []match) []match {
+	if len(matches) < 2 {
+		return matches
+	}
+	window := e.config.GroupWindow
+	if window <= 0 {
+		window = defaultGroupWindow
+	}
+
+	merged := matches[:1]
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		group := e.matchGroup(m)
+		if group == "" || group != e.matchGroup(*last) || !groupGap(text[last.end:m.start], window) {
+			merged = append(merged, m)
+			continue
+		}
+		last.end = m.end
+		last.name = group
+	}
+	return merged
+}
+
+// matchGroup returns the GroupID of a match's pattern, or "" for matches
+// reported by detectors, which carry their own labels.
+func (e *RedactionEngine) matchGroup(m match) string {
+	p := e.patterns[m.pattern]
+	if p.detector != nil {
+		return ""
+	}
+	return p.GroupID
+}
+
+// groupGap reports whether gap may be swallowed when merging two group
+// matches: it is at most window bytes and has no letters or digits.
+func groupGap(gap string, window int) bool {
+	return len(gap) <= window && !strings.ContainsFunc(gap, isWordRune)
+}
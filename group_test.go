@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_GroupID tests merging adjacent matches of a pattern group
+func TestRedactionEngine_GroupID(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{
+			Name:    "STREET",
+			Regex:   regexp.MustCompile(`\b\d{1,5} [A-Z][a-z]+ (?:St|Ave|Rd)\b\.?`),
+			GroupID: "ADDRESS",
+		},
+		{
+			Name:    "CITY",
+			Regex:   regexp.MustCompile(`\b[A-Z][a-z]+, [A-Z]{2} \d{5}\b`),
+			GroupID: "ADDRESS",
+		},
+		{
+			Name:  "ROOM",
+			Regex: regexp.MustCompile(`\bRoom \d+\b`),
+		},
+	}
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Ship to:\n123 Main St.\nSpringfield, IL 62704\nThanks", "Ship to:\n[ADDRESS]\nThanks"},
+		{"123 Main St, Springfield, IL 62704", "[ADDRESS]"},
+		// A lone group match keeps its own label
+		{"Meet at 123 Main St today", "Meet at [STREET] today"},
+		// Words between the parts keep them apart
+		{"123 Main St is near Springfield, IL 62704", "[STREET] is near [CITY]"},
+		// Patterns outside the group are never merged into it
+		{"Room 12\nSpringfield, IL 62704", "[ROOM]\n[CITY]"},
+		// Parts further apart than GroupWindow stay separate
+		{"123 Main St." + strings.Repeat("\n", 17) + "Springfield, IL 62704", "[STREET]" + strings.Repeat("\n", 17) + "[CITY]"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %q\nExpected: %q\nGot: %q", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Offsets of the merged redaction span every part
+	input := "Ship to:\n123 Main St.\nSpringfield, IL 62704\nThanks"
+	_, details := engine.RedactDetailed(input)
+	if len(details) != 1 || details[0].PatternName != "ADDRESS" || details[0].Start != 9 || details[0].End != 43 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
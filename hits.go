@@ -0,0 +1,34 @@
+package piiredact
+
+// PatternHits runs detection over a sample of chunks, without redacting
+// them, and returns how many values each pattern found, for deciding which
+// patterns to keep. Every pattern enabled on the engine is in the result,
+// with a count of zero if it never matched; labels reported only by
+// detectors or pattern groups appear once they are found.
+//
+// Values are counted as Detect reports them, after overlaps are resolved,
+// so a pattern whose matches are always claimed by another one counts
+// zero. Unlike Process, PatternHits does not update metrics or write audit
+// records, so the engine's running totals are unaffected.
+func (e *RedactionEngine) PatternHits(chunks []Chunk) map[string]int {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	hits := make(map[string]int)
+	for _, p := range e.patterns {
+		if p.detector == nil {
+			hits[p.Name] = 0
+		}
+	}
+
+	for _, c := range chunks {
+		if isEmptyChunk(c) {
+			continue
+		}
+		// A failed detector only leaves its own label undercounted
+		detections, _ := e.detectText(c.Text)
+		for _, d := range detections {
+			hits[d.PatternName]++
+		}
+	}
+	return hits
+}
@@ -0,0 +1,220 @@
+package piiredact
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTMLOptions controls RedactHTML.
+type HTMLOptions struct {
+	Attributes []string // Attribute names whose values are also redacted, e.g. "href", "title", "alt"
+}
+
+// RedactHTML redacts the text of an HTML document or fragment, leaving its
+// markup intact.
+//
+// Text between tags is entity-decoded, redacted and, only if something
+// changed, re-encoded, so "jane&#64;example.com" is found as an email and
+// untouched text keeps its original entities. The bodies of comments and
+// of script and style elements are redacted as raw text, without entity
+// decoding, since inline JSON or commented-out markup can hold PII too.
+// Tags and doctypes are copied through unchanged, except for the values of
+// the attributes listed in opts.Attributes, which are redacted like text.
+// Matching is case-insensitive on attribute names. Markup is recognized
+// with a small tolerant scanner rather than a full HTML5 parser: malformed
+// input is never rejected, a '<' that starts no tag is treated as text, and
+// an unterminated tag or comment runs to the end of the input.
+func (e *RedactionEngine) RedactHTML(input string, opts HTMLOptions) string {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	scan := make(map[string]bool, len(opts.Attributes))
+	for _, name := range opts.Attributes {
+		scan[strings.ToLower(name)] = true
+	}
+
+	var b strings.Builder
+	b.Grow(len(input))
+	node := 0 // Number of text nodes and attributes redacted, used to label chunks
+
+	raw := func(text string) string {
+		if strings.TrimSpace(text) == "" {
+			return text
+		}
+		node++
+		return e.redactChunk(Chunk{UUID: fmt.Sprintf("html-%d", node), Text: text}).Text
+	}
+	redact := func(encoded string) string {
+		decoded := html.UnescapeString(encoded)
+		redacted := raw(decoded)
+		if redacted == decoded {
+			return encoded
+		}
+		return html.EscapeString(redacted)
+	}
+
+	textStart := 0
+	for i := 0; i < len(input); {
+		lt := strings.IndexByte(input[i:], '<')
+		if lt < 0 {
+			break
+		}
+		i += lt
+		rest := input[i:]
+
+		// Find where this piece of markup ends; a '<' that starts no markup is text
+		var n int
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			if end := strings.Index(rest[4:], "-->"); end >= 0 {
+				n = 4 + end + 3
+			} else {
+				n = len(rest)
+			}
+		case len(rest) > 1 && (isASCIILetter(rune(rest[1])) || strings.ContainsRune("/!?", rune(rest[1]))):
+			if n = htmlTagEnd(rest); n < 0 {
+				n = len(rest)
+			}
+		default:
+			i++
+			continue
+		}
+
+		b.WriteString(redact(input[textStart:i]))
+		tag := rest[:n]
+		switch {
+		case strings.HasPrefix(tag, "<!--"):
+			body, closed := strings.CutSuffix(tag[4:], "-->")
+			tag = "<!--" + raw(body)
+			if closed {
+				tag += "-->"
+			}
+		case isASCIILetter(rune(tag[1])) && len(scan) > 0:
+			tag = redactHTMLAttributes(tag, scan, redact)
+		}
+		b.WriteString(tag)
+		i += n
+
+		// Redact script and style bodies as raw text up to their end tag
+		if name := htmlTagName(rest[:n]); name == "script" || name == "style" {
+			end := strings.Index(strings.ToLower(input[i:]), "</"+name)
+			if end < 0 {
+				end = len(input) - i
+			}
+			b.WriteString(raw(input[i : i+end]))
+			i += end
+		}
+		textStart = i
+	}
+	b.WriteString(redact(input[textStart:]))
+
+	return b.String()
+}
+
+// htmlTagEnd returns the length of the tag at the start of s, up to and
+// including its closing '>', skipping '>' inside quoted attribute values.
+// It returns -1 if the tag is not terminated.
+func htmlTagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// htmlTagName returns the lowercase name of an opening tag, or "" for
+// closing tags, comments and declarations.
+func htmlTagName(tag string) string {
+	if len(tag) < 2 || !isASCIILetter(rune(tag[1])) {
+		return ""
+	}
+	end := 1
+	for end < len(tag) && !strings.ContainsRune(" \t\r\n\f/>", rune(tag[end])) {
+		end++
+	}
+	return strings.ToLower(tag[1:end])
+}
+
+// redactHTMLAttributes applies redact to the values of the attributes of
+// an opening tag that are named in scan. Values that change are written
+// back double-quoted; everything else is kept byte-for-byte.
+func redactHTMLAttributes(tag string, scan map[string]bool, redact func(string) string) string {
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
+
+	var b strings.Builder
+	i := len(htmlTagName(tag)) + 1
+	b.WriteString(tag[:i])
+
+	for i < len(tag) {
+		// Copy whitespace, slashes and the closing '>'
+		if c := tag[i]; isSpace(c) || c == '/' || c == '>' {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		// Attribute name
+		nameStart := i
+		for i < len(tag) && !isSpace(tag[i]) && tag[i] != '=' && tag[i] != '>' && tag[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(tag[nameStart:i])
+
+		// Optional "=" and value, with whitespace allowed around "="
+		j := i
+		for j < len(tag) && isSpace(tag[j]) {
+			j++
+		}
+		if j >= len(tag) || tag[j] != '=' {
+			b.WriteString(tag[nameStart:i])
+			continue
+		}
+		j++
+		for j < len(tag) && isSpace(tag[j]) {
+			j++
+		}
+
+		var value string
+		var valueStart, valueEnd int
+		if j < len(tag) && (tag[j] == '"' || tag[j] == '\'') {
+			valueStart = j + 1
+			valueEnd = valueStart + strings.IndexByte(tag[valueStart:], tag[j])
+			if valueEnd < valueStart {
+				valueEnd = len(tag) // Unterminated quote; htmlTagEnd ran to the end
+			}
+			value = tag[valueStart:valueEnd]
+			j = min(valueEnd+1, len(tag))
+		} else {
+			valueStart = j
+			for j < len(tag) && !isSpace(tag[j]) && tag[j] != '>' {
+				j++
+			}
+			valueEnd = j
+			value = tag[valueStart:valueEnd]
+		}
+
+		redacted := value
+		if scan[name] {
+			redacted = redact(value)
+		}
+		if redacted == value {
+			b.WriteString(tag[nameStart:j])
+		} else {
+			b.WriteString(tag[nameStart:i])
+			b.WriteString(`="`)
+			b.WriteString(redacted)
+			b.WriteByte('"')
+		}
+		i = j
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package piiredact
+
+import "testing"
+
+// TestRedactHTML tests that text nodes are redacted and markup is preserved
+func TestRedactHTML(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     HTMLOptions
+		expected string
+	}{
+		{
+			name:     "anchor text",
+			input:    `<p>Write to <a href="mailto:jane@example.com">jane@example.com</a>.</p>`,
+			expected: `<p>Write to <a href="mailto:jane@example.com">[EMAIL]</a>.</p>`,
+		},
+		{
+			name:     "scanned attribute",
+			input:    `<p>Write to <a href="mailto:jane@example.com" class=link>jane@example.com</a>.</p>`,
+			opts:     HTMLOptions{Attributes: []string{"HREF"}},
+			expected: `<p>Write to <a href="mailto:[EMAIL]" class=link>[EMAIL]</a>.</p>`,
+		},
+		{
+			name:     "unquoted attribute is quoted when changed",
+			input:    `<img alt=555-123-4567 src=a.png>`,
+			opts:     HTMLOptions{Attributes: []string{"alt"}},
+			expected: `<img alt="[PHONE]" src=a.png>`,
+		},
+		{
+			name:     "entities decoded before matching",
+			input:    `<td>jane&#64;example.com &amp; SSN 123-45-6789</td>`,
+			expected: `<td>[EMAIL] &amp; SSN [SSN]</td>`,
+		},
+		{
+			name:     "unchanged text keeps its entities",
+			input:    `<p>Fish &amp; chips&nbsp;&#33;</p>`,
+			expected: `<p>Fish &amp; chips&nbsp;&#33;</p>`,
+		},
+		{
+			name:     "PII split across tags",
+			input:    `<b>SSN</b> <i>123-45-6789</i>`,
+			expected: `<b>SSN</b> <i>[SSN]</i>`,
+		},
+		{
+			name:     "comment bodies redacted",
+			input:    `<!-- 123-45-6789 --><p>ok</p><!---->`,
+			expected: `<!-- [SSN] --><p>ok</p><!---->`,
+		},
+		{
+			name:     "unterminated comment redacted",
+			input:    `<p>ok</p><!-- jane@example.com`,
+			expected: `<p>ok</p><!-- [EMAIL]`,
+		},
+		{
+			name:     "script and style bodies redacted as raw text",
+			input:    `<script>var s = {"ssn":"123-45-6789","q":"a&amp;b"};</script><STYLE>p{}</STYLE>123-45-6789`,
+			expected: `<script>var s = {"ssn":"[SSN]","q":"a&amp;b"};</script><STYLE>p{}</STYLE>[SSN]`,
+		},
+		{
+			name:     "script without PII untouched",
+			input:    `<script>if (a < b && c > d) { go(); }</script>`,
+			expected: `<script>if (a < b && c > d) { go(); }</script>`,
+		},
+		{
+			name:     "greater-than inside quoted attribute",
+			input:    `<a title="a > b" href="x">user@example.com</a>`,
+			expected: `<a title="a > b" href="x">[EMAIL]</a>`,
+		},
+		{
+			name:     "stray less-than is text",
+			input:    `1 < 2 and call 555-123-4567`,
+			expected: `1 &lt; 2 and call [PHONE]`,
+		},
+		{
+			name:     "unterminated tag copied through",
+			input:    `call 555-123-4567 <a href="555-123-4567`,
+			opts:     HTMLOptions{Attributes: []string{"href"}},
+			expected: `call [PHONE] <a href="[PHONE]"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.RedactHTML(tt.input, tt.opts); got != tt.expected {
+				t.Errorf("RedactHTML(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestValidateIMEI tests the IMEI length and Luhn check digit
+func TestValidateIMEI(t *testing.T) {
+	testCases := []struct {
+		imei  string
+		valid bool
+	}{
+		{"490154203237518", true},
+		{"35-209900-176148-1", true},
+		{"35 209900 176148 1", true},
+		{"490154203237519", false}, // Bad check digit
+		{"4901542032375", false},   // Too short
+		{"4111111111111111", false},
+	}
+
+	for _, tc := range testCases {
+		if got := validateIMEI(tc.imei); got != tc.valid {
+			t.Errorf("validateIMEI(%q) = %v, expected %v", tc.imei, got, tc.valid)
+		}
+	}
+}
+
+// TestRedactionEngine_IMEI tests that IMEI is opt-in and optionally cue-gated
+func TestRedactionEngine_IMEI(t *testing.T) {
+	inputs := []string{
+		"The IMEI is 490154203237518",
+		"Order 490154203237518 shipped",
+		"Device 35-209900-176148-1 reset",
+		"IMEI 490154203237519",
+	}
+
+	testCases := []struct {
+		name     string
+		enabled  bool
+		cues     bool
+		expected []string
+	}{
+		{"default", false, false, []string{
+			"The IMEI is 490154203237518",
+			"Order 490154203237518 shipped",
+			"Device 35-209900-176148-1 reset",
+			"IMEI 490154203237519",
+		}},
+		{"enabled", true, false, []string{
+			"The IMEI is [IMEI]",
+			"Order [IMEI] shipped",
+			"Device [IMEI] reset",
+			"IMEI 490154203237519",
+		}},
+		{"cues", true, true, []string{
+			"The IMEI is [IMEI]",
+			"Order 490154203237518 shipped",
+			"Device 35-209900-176148-1 reset",
+			"IMEI 490154203237519",
+		}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		if tc.enabled {
+			config.EnabledPatterns["IMEI"] = true
+		}
+		if tc.cues {
+			config.RequireCues = map[string]bool{"IMEI": true}
+		}
+		engine := NewRedactionEngine(config)
+
+		for i, input := range inputs {
+			result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+			if result[0].Text != tc.expected[i] {
+				t.Errorf("%s: Input: %s\nExpected: %s\nGot: %s", tc.name, input, tc.expected[i], result[0].Text)
+			}
+		}
+	}
+
+	if valid, err := ValidateValue("IMEI", "490154203237518"); !valid || err != nil {
+		t.Errorf("ValidateValue(IMEI) = %v, %v", valid, err)
+	}
+}
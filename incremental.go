@@ -0,0 +1,28 @@
+package piiredact
+
+// RedactNew redacts only the PII in text that is not already in baseline,
+// for document-versioning workflows that reprocess each revision: values
+// the previous revision already contained, and which were handled when it
+// was redacted, are left as they are, and only values new to this revision
+// are replaced.
+//
+// Both texts are scanned with the engine's patterns; a value in text is
+// left alone if the same string was detected anywhere in baseline, under
+// any pattern. An empty baseline redacts everything, as Process would.
+// Metrics and audit records are updated for the new values only.
+func (e *RedactionEngine) RedactNew(baseline, text string) string {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	// A detector failing on baseline only means fewer values are known, so
+	// more of text is redacted
+	known := make(map[string]bool)
+	baselineDetections, _ := e.detectText(baseline)
+	for _, d := range baselineDetections {
+		known[d.Value] = true
+	}
+
+	r := e.newRedaction()
+	defer r.release()
+	r.known = known
+	return e.redactChunkWith(Chunk{Text: text}, r).Text
+}
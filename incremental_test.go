@@ -0,0 +1,48 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_RedactNew tests redacting only values new to a revision
+func TestRedactionEngine_RedactNew(t *testing.T) {
+	baseline := "Contact jane@example.com or call 404-555-1212. SSN on file: 123-45-6789."
+
+	testCases := []struct {
+		revision string
+		expected string
+	}{
+		// Nothing new
+		{baseline, baseline},
+		// A new phone number and a changed SSN are redacted, the rest is kept
+		{
+			"Contact jane@example.com or call 404-555-3434. SSN on file: 401-23-4567.",
+			"Contact jane@example.com or call [PHONE]. SSN on file: [SSN].",
+		},
+		// Known values are kept wherever they move to
+		{
+			"SSN 123-45-6789 now belongs with bob@example.com, not jane@example.com",
+			"SSN 123-45-6789 now belongs with [EMAIL], not jane@example.com",
+		},
+	}
+
+	engine := NewRedactionEngine(DefaultConfig())
+	for _, tc := range testCases {
+		if got := engine.RedactNew(baseline, tc.revision); got != tc.expected {
+			t.Errorf("Revision: %s\nExpected: %s\nGot: %s", tc.revision, tc.expected, got)
+		}
+	}
+
+	// An empty baseline redacts everything
+	want, _ := engine.Process([]Chunk{{Text: baseline}})
+	if got := engine.RedactNew("", baseline); got != want[0].Text {
+		t.Errorf("Expected %q, got %q", want[0].Text, got)
+	}
+
+	// Only the new values are counted
+	engine.ResetMetrics()
+	engine.RedactNew(baseline, "call 404-555-1212 or 404-555-3434")
+	if n := engine.GetMetrics().RedactedItems["PHONE"]; n != 1 {
+		t.Errorf("Expected 1 PHONE redaction counted, got %d", n)
+	}
+}
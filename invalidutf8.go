@@ -0,0 +1,45 @@
+package piiredact
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// UTF8Policy selects what happens to chunks whose Text is not valid
+// UTF-8, for example after a bad decode upstream.
+type UTF8Policy int
+
+const (
+	// UTF8Ignore, the default, does not check chunks; invalid bytes
+	// are passed through around any redactions.
+	UTF8Ignore UTF8Policy = iota
+
+	// UTF8Sanitize replaces each run of invalid bytes with U+FFFD
+	// before detection, so the output is always valid UTF-8.
+	UTF8Sanitize
+
+	// UTF8Flag leaves the text unchanged but counts and logs the
+	// chunk, so bad input can be traced without altering it.
+	UTF8Flag
+)
+
+// checkUTF8 applies Config.InvalidUTF8 to a chunk, counting it in
+// Metrics.InvalidUTF8 if its text is not valid UTF-8. It reports false
+// for such chunks, unless the policy is UTF8Ignore.
+func (e *RedactionEngine) checkUTF8(c Chunk) (Chunk, bool) {
+	if e.config.InvalidUTF8 == UTF8Ignore || utf8.ValidString(c.Text) {
+		return c, true
+	}
+
+	e.metrics.mu.Lock()
+	e.metrics.InvalidUTF8++
+	e.metrics.mu.Unlock()
+	if e.config.Logging && e.logger != nil {
+		e.logger.Printf("Chunk %s: text is not valid UTF-8", c.UUID)
+	}
+
+	if e.config.InvalidUTF8 == UTF8Sanitize {
+		c.Text = strings.ToValidUTF8(c.Text, "\uFFFD")
+	}
+	return c, false
+}
@@ -0,0 +1,65 @@
+package piiredact
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// TestRedactionEngine_InvalidUTF8 tests chunks containing invalid byte sequences
+func TestRedactionEngine_InvalidUTF8(t *testing.T) {
+	inputs := []string{
+		"SSN 401-23-4567 \xff\xfe end",
+		"\xc3SSN\xc3 401-23-4567\xe2\x82",
+		"email jane@example.com\x80",
+		"valid text 555-123-4567",
+	}
+
+	testCases := []struct {
+		policy   UTF8Policy
+		expected []string
+		invalid  int64
+	}{
+		{UTF8Ignore, []string{
+			"SSN [SSN] \xff\xfe end",
+			"\xc3SSN\xc3 [SSN]\xe2\x82",
+			"email [EMAIL]\x80",
+			"valid text [PHONE]",
+		}, 0},
+		{UTF8Sanitize, []string{
+			"SSN [SSN] \uFFFD end",
+			"\uFFFDSSN\uFFFD [SSN]\uFFFD",
+			"email [EMAIL]\uFFFD",
+			"valid text [PHONE]",
+		}, 3},
+		{UTF8Flag, []string{
+			"SSN [SSN] \xff\xfe end",
+			"\xc3SSN\xc3 [SSN]\xe2\x82",
+			"email [EMAIL]\x80",
+			"valid text [PHONE]",
+		}, 3},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.InvalidUTF8 = tc.policy
+		engine := NewRedactionEngine(config)
+
+		chunks := make([]Chunk, len(inputs))
+		for i, input := range inputs {
+			chunks[i] = Chunk{UUID: "u", Speaker: "A", Text: input}
+		}
+		result, _ := engine.Process(chunks)
+
+		for i := range inputs {
+			if result[i].Text != tc.expected[i] {
+				t.Errorf("Policy %d: Input: %q\nExpected: %q\nGot: %q", tc.policy, inputs[i], tc.expected[i], result[i].Text)
+			}
+			if tc.policy == UTF8Sanitize && !utf8.ValidString(result[i].Text) {
+				t.Errorf("Policy %d: output %q is not valid UTF-8", tc.policy, result[i].Text)
+			}
+		}
+		if n := engine.GetMetrics().InvalidUTF8; n != tc.invalid {
+			t.Errorf("Policy %d: expected %d invalid chunks in metrics, got %d", tc.policy, tc.invalid, n)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package piiredact
+
+// versionCues are the words that mark a following dotted number as a
+// software version rather than an IPv4 address.
+var versionCues = []string{"version", "ver", "v", "release", "build"}
+
+// versionConnectors may come between a version cue and the number, as in
+// "ver. 1.2.3.4" or "version: 1.2.3.4".
+var versionConnectors = []string{":", ".", "=", "is"}
+
+// notVersion is a ContextValidate for IP that rejects matches directly
+// preceded by a version cue, as in "version 1.2.3.4" or "v 10.0.0.1".
+// Text such as "v1.2.3.4" never matches IP, since the "v" leaves no word
+// boundary before the number.
+func notVersion(text string, start, end int) bool {
+	_, _, ok := cueBefore(text, start, versionCues, versionConnectors)
+	return !ok
+}
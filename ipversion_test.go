@@ -0,0 +1,41 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_SkipIPVersions tests telling version strings from IP addresses
+func TestRedactionEngine_SkipIPVersions(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"version 1.2.3.4", "version 1.2.3.4"},
+		{"upgraded to Version: 10.0.0.1 today", "upgraded to Version: 10.0.0.1 today"},
+		{"ver. 2.4.1.0 is out", "ver. 2.4.1.0 is out"},
+		{"the build is 5.6.7.8", "the build is 5.6.7.8"},
+		{"v 1.2.3.4", "v 1.2.3.4"},
+		{"IP 1.2.3.4", "IP [IP]"},
+		{"server 10.0.0.1 version 1.2.3.4", "server [IP] version 1.2.3.4"},
+		// The cue must be a word of its own and come directly before the value
+		{"conversion 1.2.3.4", "conversion [IP]"},
+		{"version two runs on 1.2.3.4", "version two runs on [IP]"},
+	}
+
+	config := DefaultConfig()
+	config.SkipIPVersions = true
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without the option versions are redacted like any address
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: "version 1.2.3.4"}})
+	if expected := "version [IP]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
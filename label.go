@@ -0,0 +1,58 @@
+package piiredact
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// LabelData is what Config.LabelTemplate is executed with for each
+// redaction. For example, with
+//
+//	{{.Pattern}}#{{.Index}} ending {{.Value}}
+//
+// an SSN becomes "SSN#1 ending XXX-XX-6789".
+type LabelData struct {
+	Pattern    string  // Name of the pattern that matched, e.g. "SSN"
+	Value      string  // The value masked as in ModeMask, e.g. "XXX-XX-6789"
+	Index      int     // Value's number with Numbering, else its position among the chunk's redactions of Pattern, from 1
+	Confidence float64 // Confidence of the match, as in Detection
+}
+
+// ParseLabelTemplate parses text as a LabelTemplate and checks that it
+// executes with LabelData, so a mistake such as an unknown field is
+// reported here rather than while redacting.
+func ParseLabelTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("label").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("piiredact: label template: %w", err)
+	}
+	sample := LabelData{Pattern: "SSN", Value: "XXX-XX-6789", Index: 1, Confidence: 1}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("piiredact: label template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templateLabel renders the label of a value with Config.LabelTemplate. If
+// the template fails, the value is labelled with RedactionFormat and the
+// failure recorded as a problem with the chunk.
+func (e *RedactionEngine) templateLabel(m match, name, value string, r *redaction) string {
+	data := LabelData{
+		Pattern:    name,
+		Value:      e.mask(value),
+		Index:      r.counts[name] + 1,
+		Confidence: e.confidence(m),
+	}
+	if r.numbers != nil {
+		data.Index = r.numbers.index(name, value)
+	}
+
+	var b strings.Builder
+	if err := e.config.LabelTemplate.Execute(&b, data); err != nil {
+		r.problems = append(r.problems, ChunkError{Kind: ChunkLabelFailed, Message: err.Error()})
+		return fmt.Sprintf(e.config.RedactionFormat, name)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package piiredact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// TestRedactionEngine_LabelTemplate tests rendering labels from a template
+func TestRedactionEngine_LabelTemplate(t *testing.T) {
+	tmpl, err := ParseLabelTemplate(`[{{.Pattern}}#{{.Index}} ending {{.Value}}]`)
+	if err != nil {
+		t.Fatalf("ParseLabelTemplate returned error: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.LabelTemplate = tmpl
+	engine := NewRedactionEngine(config)
+
+	text := "SSN 401-23-4567, card 4111 1111 1111 1111, other SSN 402-34-5678"
+	result, err := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: text}})
+	if err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+	expected := "SSN [SSN#1 ending XXX-XX-4567], card [CC#1 ending XXXX XXXX XXXX 1111], other SSN [SSN#2 ending XXX-XX-5678]"
+	if result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_LabelTemplateNumbering tests that Index follows Numbering
+func TestRedactionEngine_LabelTemplateNumbering(t *testing.T) {
+	config := DefaultConfig()
+	config.Numbering = NumberingChunk
+	config.LabelTemplate = template.Must(ParseLabelTemplate(`<{{.Pattern}} {{.Index}} {{printf "%.1f" .Confidence}}>`))
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "401-23-4567 then 402-34-5678 then 401-23-4567"}})
+	if expected := "<SSN 1 1.0> then <SSN 2 1.0> then <SSN 1 1.0>"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestParseLabelTemplate_Invalid tests that broken templates are rejected up front
+func TestParseLabelTemplate_Invalid(t *testing.T) {
+	for _, text := range []string{`[{{.Pattern}]`, `[{{.Label}}]`, `{{template "missing"}}`} {
+		if _, err := ParseLabelTemplate(text); err == nil || !strings.HasPrefix(err.Error(), "piiredact: label template") {
+			t.Errorf("ParseLabelTemplate(%q) error = %v, expected a label template error", text, err)
+		}
+	}
+}
+
+// TestRedactionEngine_LabelTemplateFailure tests that a template failing at
+// redaction time falls back to RedactionFormat and is reported
+func TestRedactionEngine_LabelTemplateFailure(t *testing.T) {
+	config := DefaultConfig()
+	config.LabelTemplate = template.Must(template.New("label").Parse(`{{if eq .Pattern "SSN"}}{{.Missing}}{{end}}[{{.Pattern}}]`))
+	engine := NewRedactionEngine(config)
+
+	result, err := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "SSN 401-23-4567 mail jane@example.com"}})
+	if expected := "SSN [SSN] mail [EMAIL]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+
+	var pe *ProcessError
+	if !errors.As(err, &pe) || len(pe.Chunks) != 1 || pe.Chunks[0].Kind != ChunkLabelFailed {
+		t.Fatalf("Expected a ProcessError with one ChunkLabelFailed, got %v", err)
+	}
+	if pe.Chunks[0].Kind.String() != "label template failed" {
+		t.Errorf("Unexpected kind name %q", pe.Chunks[0].Kind.String())
+	}
+}
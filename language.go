@@ -0,0 +1,36 @@
+package piiredact
+
+// Per-language patterns.
+//
+// Multilingual call centres often want some patterns only for some
+// languages: a US driver's license shape is noise in Spanish text, say.
+// Config.LanguagePatterns maps a language tag, compared exactly with
+// Chunk.Lang, to the names of the patterns to run on chunks in that
+// language. Every other pattern is skipped for those chunks.
+//
+// Unknown languages fail safe: a chunk whose Lang is empty or has no entry
+// in the map is scanned with every active pattern, as if LanguagePatterns
+// were unset. External detectors (Config.Detectors and the spoken-digit
+// detector) are not named patterns and always run. Naming a pattern that is
+// not active enables nothing.
+
+// languageSkips returns, for each language in languages, which of patterns
+// to skip, indexed like patterns. It returns nil if languages is empty.
+func languageSkips(patterns []PatternDef, languages map[string][]string) map[string][]bool {
+	if len(languages) == 0 {
+		return nil
+	}
+	skips := make(map[string][]bool, len(languages))
+	for lang, names := range languages {
+		allowed := make(map[string]bool, len(names))
+		for _, name := range names {
+			allowed[name] = true
+		}
+		skip := make([]bool, len(patterns))
+		for i, p := range patterns {
+			skip[i] = p.detector == nil && !allowed[p.Name]
+		}
+		skips[lang] = skip
+	}
+	return skips
+}
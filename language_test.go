@@ -0,0 +1,70 @@
+package piiredact
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestRedactionEngine_LanguagePatterns tests limiting patterns by Chunk.Lang
+func TestRedactionEngine_LanguagePatterns(t *testing.T) {
+	text := "SSN 401-23-4567, email jane@example.com"
+	testCases := []struct {
+		lang     string
+		expected string
+	}{
+		{"en", "SSN [SSN], email [EMAIL]"},
+		{"es", "SSN 401-23-4567, email [EMAIL]"},
+		// Unknown and missing languages run every pattern
+		{"fr", "SSN [SSN], email [EMAIL]"},
+		{"", "SSN [SSN], email [EMAIL]"},
+	}
+
+	config := DefaultConfig()
+	config.LanguagePatterns = map[string][]string{
+		"en": {"SSN", "EMAIL"},
+		"es": {"EMAIL"},
+	}
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: text, Lang: tc.lang}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Lang: %q\nExpected: %s\nGot: %s", tc.lang, tc.expected, result[0].Text)
+		}
+		if result[0].Lang != tc.lang {
+			t.Errorf("Lang: %q\nGot Lang: %q", tc.lang, result[0].Lang)
+		}
+	}
+}
+
+// TestRedactionEngine_LanguagePatternsDetectors tests that detectors run for every language
+func TestRedactionEngine_LanguagePatternsDetectors(t *testing.T) {
+	config := DefaultConfig()
+	config.Detectors = []Detector{scoredDetector{"Maria": {PatternName: "NAME"}}}
+	config.LanguagePatterns = map[string][]string{"es": {"EMAIL"}}
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "soy Maria, 404-555-1212", Lang: "es"}})
+	if expected := "soy [NAME], 404-555-1212"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestChunk_LangJSON tests that Lang is optional in JSON
+func TestChunk_LangJSON(t *testing.T) {
+	data, err := json.Marshal(Chunk{UUID: "u", Speaker: "A", Text: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"uuid":"u","speaker":"A","text":"hi"}`; string(data) != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, data)
+	}
+
+	var c Chunk
+	if err := json.Unmarshal([]byte(`{"uuid":"u","speaker":"A","text":"hola","lang":"es"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Lang != "es" {
+		t.Errorf("Expected Lang es, got %q", c.Lang)
+	}
+}
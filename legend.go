@@ -0,0 +1,47 @@
+package piiredact
+
+// labelDescriptions describes labels that no pattern carries.
+var labelDescriptions = map[string]string{
+	failClosedLabel: "Chunk withheld after a detector failure",
+}
+
+// Legend explains the labels the engine has produced since it was created
+// or ResetMetrics was last called, mapping each label, such as "SSN", to
+// its description, such as "Social Security Number", so reviewers can read
+// redacted output. Descriptions come from PatternDef.Description; a label
+// with none, such as an entity type reported by a Detector, describes
+// itself. The number of each label is in GetMetrics().RedactedItems, and
+// Report pairs both.
+func (e *RedactionEngine) Legend() map[string]string {
+	counts := e.GetMetrics().RedactedItems
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.legend(counts)
+}
+
+// legend builds the Legend of the labels with a nonzero count, with the
+// caller holding configMu for reading.
+func (e *RedactionEngine) legend(counts map[string]int64) map[string]string {
+	descriptions := make(map[string]string)
+	for _, p := range e.patterns {
+		if _, ok := descriptions[p.Name]; !ok && p.Description != "" {
+			descriptions[p.Name] = p.Description
+		}
+	}
+
+	legend := make(map[string]string)
+	for name, count := range counts {
+		if count == 0 {
+			continue
+		}
+		switch d, ok := descriptions[name]; {
+		case ok:
+			legend[name] = d
+		case labelDescriptions[name] != "":
+			legend[name] = labelDescriptions[name]
+		default:
+			legend[name] = name
+		}
+	}
+	return legend
+}
@@ -0,0 +1,74 @@
+package piiredact
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestRedactionEngine_Legend tests describing the labels an engine produced
+func TestRedactionEngine_Legend(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{Name: "CASE_ID", Regex: regexp.MustCompile(`\bCAS-\d{6}\b`), Description: "Support case number"},
+		{Name: "TICKET", Regex: regexp.MustCompile(`\bTKT-\d{4}\b`)},
+	}
+	config.Detectors = []Detector{scoredDetector{"Jane": {PatternName: "PERSON"}}}
+	engine := NewRedactionEngine(config)
+
+	if legend := engine.Legend(); len(legend) != 0 {
+		t.Errorf("Expected an empty legend before processing, got %v", legend)
+	}
+
+	engine.Process([]Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Jane, SSN 123-45-6789, case CAS-123456"},
+		{UUID: "id2", Speaker: "B", Text: "ticket TKT-1234, SSN 401-23-4567"},
+	})
+
+	expected := map[string]string{
+		"SSN":     "Social Security Number",
+		"CASE_ID": "Support case number",
+		"TICKET":  "TICKET",
+		"PERSON":  "PERSON",
+	}
+	if legend := engine.Legend(); !maps.Equal(legend, expected) {
+		t.Errorf("Expected legend %v, got %v", expected, legend)
+	}
+
+	// Reports carry the same descriptions next to the counts
+	var b strings.Builder
+	if err := engine.RenderReport(&b, nil); err != nil {
+		t.Fatalf("RenderReport returned error: %v", err)
+	}
+	for _, want := range []string{"SSN           2  40.0%  high    Social Security Number", "Support case number"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Report does not contain %q:\n%s", want, b.String())
+		}
+	}
+
+	engine.ResetMetrics()
+	if legend := engine.Legend(); len(legend) != 0 {
+		t.Errorf("Expected an empty legend after ResetMetrics, got %v", legend)
+	}
+}
+
+// TestPatternSpec_Description tests that descriptions survive export and load
+func TestPatternSpec_Description(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableBuiltins = true
+	config.CustomPatterns = []PatternDef{
+		{Name: "CASE_ID", Regex: regexp.MustCompile(`\bCAS-\d{6}\b`), Description: "Support case number"},
+	}
+	data, err := NewRedactionEngine(config).ExportSpec()
+	if err != nil {
+		t.Fatalf("ExportSpec returned error: %v", err)
+	}
+	patterns, err := LoadPatterns(data)
+	if err != nil {
+		t.Fatalf("LoadPatterns returned error: %v", err)
+	}
+	if len(patterns) != 1 || patterns[0].Description != "Support case number" {
+		t.Errorf("Expected the description to round-trip, got %+v", patterns)
+	}
+}
@@ -0,0 +1,55 @@
+package piiredact
+
+import (
+	"maps"
+)
+
+// Manifest summarizes the redactions of one ProcessWithManifest call, for
+// batch-level compliance records. It holds no values or offsets; use an
+// AuditWriter or RedactDetailed for per-detection detail.
+//
+// Manifests marshal to JSON as, for example:
+//
+//	{"total_chunks":3,"chunks_with_pii":2,"pattern_counts":{"EMAIL":1,"SSN":2},"affected_uuids":["id1","id3"]}
+type Manifest struct {
+	TotalChunks   int            `json:"total_chunks"`    // Chunks passed in, including any dropped as empty
+	ChunksWithPII int            `json:"chunks_with_pii"` // Chunks with at least one redaction
+	PatternCounts map[string]int `json:"pattern_counts"`  // Redactions per pattern across the batch
+	AffectedUUIDs []string       `json:"affected_uuids"`  // UUIDs of chunks with redactions, in input order
+}
+
+// ProcessWithManifest is Process that also returns a Manifest of the batch.
+// The manifest is built from the counts recorded while each chunk is
+// redacted, so it costs no second pass over the text.
+func (e *RedactionEngine) ProcessWithManifest(chunks []Chunk) ([]Chunk, Manifest, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	counts := make([]map[string]int, len(chunks))
+	result, err := e.process(chunks, func(i int, r *redaction) {
+		if len(r.counts) > 0 {
+			counts[i] = maps.Clone(r.counts)
+		}
+	})
+	return result, newManifest(chunks, counts), err
+}
+
+// newManifest builds the manifest of a batch from its chunks and the
+// redaction counts of each, nil for chunks with no redactions.
+func newManifest(chunks []Chunk, counts []map[string]int) Manifest {
+	m := Manifest{
+		TotalChunks:   len(chunks),
+		PatternCounts: make(map[string]int),
+		AffectedUUIDs: []string{},
+	}
+	for i, chunkCounts := range counts {
+		if len(chunkCounts) == 0 {
+			continue
+		}
+		m.ChunksWithPII++
+		m.AffectedUUIDs = append(m.AffectedUUIDs, chunks[i].UUID)
+		for name, count := range chunkCounts {
+			m.PatternCounts[name] += count
+		}
+	}
+	return m
+}
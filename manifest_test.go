@@ -0,0 +1,52 @@
+package piiredact
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestRedactionEngine_ProcessWithManifest tests the batch summary
+func TestRedactionEngine_ProcessWithManifest(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 401-23-4567 and 523-45-6789"},
+		{UUID: "id2", Speaker: "B", Text: "Nothing to see here"},
+		{UUID: "id3", Speaker: "A", Text: "Email jane@example.com"},
+		{UUID: "id4", Speaker: "B", Text: " "},
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		config := DefaultConfig()
+		config.MaxConcurrency = concurrency
+		config.DropEmptyChunks = true
+		engine := NewRedactionEngine(config)
+
+		result, manifest, err := engine.ProcessWithManifest(chunks)
+		if err != nil {
+			t.Fatalf("ProcessWithManifest returned error: %v", err)
+		}
+		if len(result) != 3 || result[0].Text != "SSN [SSN] and [SSN]" {
+			t.Errorf("Unexpected result: %v", result)
+		}
+
+		expected := Manifest{
+			TotalChunks:   4,
+			ChunksWithPII: 2,
+			PatternCounts: map[string]int{"SSN": 2, "EMAIL": 1},
+			AffectedUUIDs: []string{"id1", "id3"},
+		}
+		if !reflect.DeepEqual(manifest, expected) {
+			t.Errorf("Concurrency %d: manifest = %+v, expected %+v", concurrency, manifest, expected)
+		}
+	}
+
+	// A clean batch still has the documented schema
+	_, manifest, _ := NewRedactionEngine(DefaultConfig()).ProcessWithManifest(chunks[1:2])
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"total_chunks":1,"chunks_with_pii":0,"pattern_counts":{},"affected_uuids":[]}`; string(data) != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, data)
+	}
+}
@@ -0,0 +1,43 @@
+package piiredact
+
+import (
+	"strings"
+)
+
+// Label markers.
+//
+// After redaction a literal "[SSN]" that was in the input looks just like
+// one the engine inserted. With Config.LabelMarker set, every replacement
+// the engine writes, whether a label, mask or token, is wrapped in the
+// marker, so downstream tools can find inserted redactions reliably with
+// MarkedReplacements. An invisible character such as U+2063 (INVISIBLE
+// SEPARATOR) keeps output readable; the marker must not occur in the input
+// for the pairs to be unambiguous.
+
+// MarkedReplacements returns the byte offsets of the replacements in
+// redacted text produced with Config.LabelMarker set, each span including
+// its markers. It returns nil if no marker is configured; an unpaired
+// marker at the end of text is ignored.
+func (e *RedactionEngine) MarkedReplacements(text string) [][]int {
+	e.configMu.RLock()
+	marker := e.config.LabelMarker
+	e.configMu.RUnlock()
+	if marker == "" {
+		return nil
+	}
+
+	var spans [][]int
+	for offset := 0; ; {
+		open := strings.Index(text[offset:], marker)
+		if open < 0 {
+			return spans
+		}
+		start := offset + open
+		end := strings.Index(text[start+len(marker):], marker)
+		if end < 0 {
+			return spans
+		}
+		offset = start + len(marker) + end + len(marker)
+		spans = append(spans, []int{start, offset})
+	}
+}
@@ -0,0 +1,44 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_LabelMarker tests telling inserted labels from literal ones
+func TestRedactionEngine_LabelMarker(t *testing.T) {
+	const marker = "\u2063"
+	config := DefaultConfig()
+	config.LabelMarker = marker
+	engine := NewRedactionEngine(config)
+
+	input := "Template says [SSN] and [EMAIL]; real SSN 401-23-4567, mail jane@example.com"
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	expected := "Template says [SSN] and [EMAIL]; real SSN " + marker + "[SSN]" + marker + ", mail " + marker + "[EMAIL]" + marker
+	if result[0].Text != expected {
+		t.Fatalf("Expected: %q\nGot: %q", expected, result[0].Text)
+	}
+
+	// Only the inserted labels are found
+	var found []string
+	for _, span := range engine.MarkedReplacements(result[0].Text) {
+		found = append(found, result[0].Text[span[0]:span[1]])
+	}
+	if len(found) != 2 || found[0] != marker+"[SSN]"+marker || found[1] != marker+"[EMAIL]"+marker {
+		t.Errorf("MarkedReplacements found %q", found)
+	}
+
+	// Masks are marked too
+	config.Mode = ModeMask
+	engine = NewRedactionEngine(config)
+	result, _ = engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "SSN 401-23-4567"}})
+	if expected := "SSN " + marker + "XXX-XX-4567" + marker; result[0].Text != expected {
+		t.Errorf("Expected: %q\nGot: %q", expected, result[0].Text)
+	}
+
+	// Without a marker nothing is marked or found
+	engine = NewRedactionEngine(DefaultConfig())
+	result, _ = engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if spans := engine.MarkedReplacements(result[0].Text); spans != nil {
+		t.Errorf("Expected no spans, got %v", spans)
+	}
+}
@@ -0,0 +1,128 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maskValue hides a detected value while keeping its shape.
+//
+// Every letter and digit is replaced with 'X' and separators are kept, so
+// "123-45-6789" becomes "XXX-XX-6789". The last four letters or digits are
+// left visible only when the value has at least eight of them; shorter
+// values are masked completely so nothing meaningful is revealed.
+func maskValue(value string) string {
+	alnum := 0
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alnum++
+		}
+	}
+
+	keep := 0
+	if alnum >= 8 {
+		keep = 4
+	}
+
+	masked := []rune(value)
+	seen := 0
+	for i, r := range masked {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		seen++
+		if seen <= alnum-keep {
+			masked[i] = 'X'
+		}
+	}
+	return string(masked)
+}
+
+// ssnMaskRegex matches a whole SSN, or one already masked as "XXX-XX-6789",
+// capturing the separator after the area and after the group.
+var ssnMaskRegex = regexp.MustCompile(`^(?:\d{3}|XXX)([-. ]?)(?:\d{2}|XX)([-. ]?)(\d{4})$`)
+
+// MaskSSN masks all but the last four digits of an SSN, keeping the
+// separators the input used: "123-45-6789" becomes "XXX-XX-6789",
+// "123.45.6789" becomes "XXX.XX.6789", "123 45 6789" becomes "XXX XX 6789"
+// and "123456789" becomes "XXXXX6789".
+// Already masked input is returned unchanged, and values that are not
+// shaped like an SSN are returned as is.
+func MaskSSN(ssn string) string {
+	m := ssnMaskRegex.FindStringSubmatch(ssn)
+	if m == nil {
+		return ssn
+	}
+	return "XXX" + m[1] + "XX" + m[2] + m[3]
+}
+
+// minMasked is the number of letters and digits that stay masked no matter
+// how KeepPrefix and KeepSuffix are set.
+const minMasked = 4
+
+// mask masks a value for ModeMask. Without KeepPrefix or KeepSuffix it
+// behaves like maskValue.
+//
+// With EmailPreservePlusTag, the "+tag" of an email address is kept in
+// the clear and the rest is masked as if the tag were not there, so
+// "user+newsletter@example.com" becomes "XXXX+newsletter@XXXXXXe.com".
+func (e *RedactionEngine) mask(value string) string {
+	if e.config.EmailPreservePlusTag {
+		if at := strings.LastIndexByte(value, '@'); at > 0 {
+			if plus := strings.IndexByte(value[:at], '+'); plus > 0 {
+				base := value[:plus] + value[at:]
+				masked := []rune(e.maskPlain(base))
+				split := utf8.RuneCountInString(value[:plus])
+				return string(masked[:split]) + value[plus:at] + string(masked[split:])
+			}
+		}
+	}
+	return e.maskPlain(value)
+}
+
+// maskPlain masks a value for ModeMask according to KeepPrefix and KeepSuffix.
+func (e *RedactionEngine) maskPlain(value string) string {
+	if e.config.KeepPrefix <= 0 && e.config.KeepSuffix <= 0 {
+		return maskValue(value)
+	}
+	return maskKeeping(value, e.config.KeepPrefix, e.config.KeepSuffix)
+}
+
+// maskKeeping masks every letter and digit of value except the first
+// prefix and the last suffix, keeping separators, so with prefix 3 and
+// suffix 4 "4111-1111-1111-1111" becomes "411X-XXXX-XXXX-1111".
+//
+// At least minMasked letters and digits are always masked: if the value is
+// too short for both counts, the prefix is reduced first and then the
+// suffix, so values of four or fewer letters and digits are masked
+// completely.
+func maskKeeping(value string, prefix, suffix int) string {
+	alnum := 0
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alnum++
+		}
+	}
+
+	prefix, suffix = max(prefix, 0), max(suffix, 0)
+	if excess := prefix + suffix - (alnum - minMasked); excess > 0 {
+		cut := min(excess, prefix)
+		prefix -= cut
+		suffix = max(suffix-(excess-cut), 0)
+	}
+
+	masked := []rune(value)
+	seen := 0
+	for i, r := range masked {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		seen++
+		if seen > prefix && seen <= alnum-suffix {
+			masked[i] = 'X'
+		}
+	}
+	return string(masked)
+}
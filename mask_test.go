@@ -0,0 +1,125 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestMaskKeeping tests prefix and suffix masking across value lengths
+func TestMaskKeeping(t *testing.T) {
+	testCases := []struct {
+		value    string
+		prefix   int
+		suffix   int
+		expected string
+	}{
+		{"4111-1111-1111-1111", 3, 4, "411X-XXXX-XXXX-1111"},
+		{"4111111111111111", 6, 4, "411111XXXXXX1111"},
+		{"jane.doe@example.com", 2, 3, "jaXX.XXX@XXXXXXX.com"},
+		// Nine digits leave room for five kept: the prefix gives way first
+		{"401-23-4567", 3, 4, "4XX-XX-4567"},
+		{"401-23-4567", 0, 9, "XXX-X3-4567"},
+		{"12345678", 4, 4, "XXXX5678"},
+		// Four or fewer letters and digits are always fully masked
+		{"1234", 2, 2, "XXXX"},
+		{"ab", 1, 1, "XX"},
+		{"", 3, 4, ""},
+		{"12-34-56", 0, 0, "XX-XX-XX"},
+	}
+
+	for _, tc := range testCases {
+		if got := maskKeeping(tc.value, tc.prefix, tc.suffix); got != tc.expected {
+			t.Errorf("maskKeeping(%q, %d, %d) = %q, expected %q", tc.value, tc.prefix, tc.suffix, got, tc.expected)
+		}
+	}
+}
+
+// TestRedactionEngine_ModeMask tests masking detected values
+func TestRedactionEngine_ModeMask(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = ModeMask
+	engine := NewRedactionEngine(config)
+
+	input := "SSN 401-23-4567, card 4111 1111 1111 1111"
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "SSN XXX-XX-4567, card XXXX XXXX XXXX 1111"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+
+	config.KeepPrefix = 1
+	config.KeepSuffix = 4
+	engine = NewRedactionEngine(config)
+	result, _ = engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "SSN 4XX-XX-4567, card 4XXX XXXX XXXX 1111"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_EmailPreservePlusTag tests keeping plus-tags visible in masked emails
+func TestRedactionEngine_EmailPreservePlusTag(t *testing.T) {
+	input := "From user+newsletter@example.com and jane@example.com"
+
+	testCases := []struct {
+		mode     RedactionMode
+		prefix   int
+		expected string
+	}{
+		{ModeMask, 0, "From XXXX+newsletter@XXXXXXe.com and XXXX@XXXXXXe.com"},
+		{ModeMask, 2, "From usXX+newsletter@XXXXXXX.com and jaXX@XXXXXXX.com"},
+		// Full redaction is unaffected
+		{ModeLabel, 0, "From [EMAIL] and [EMAIL]"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.Mode = tc.mode
+		config.EmailPreservePlusTag = true
+		if tc.prefix > 0 {
+			config.KeepPrefix, config.KeepSuffix = tc.prefix, 3
+		}
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Mode %d: Expected: %s\nGot: %s", tc.mode, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without the option the tag is masked like the rest
+	config := DefaultConfig()
+	config.Mode = ModeMask
+	result, _ := NewRedactionEngine(config).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "From XXXX+XXXXXXXXXX@XXXXXXe.com and XXXX@XXXXXXe.com"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestMaskSSN tests that MaskSSN keeps the input's separators
+func TestMaskSSN(t *testing.T) {
+	testCases := []struct {
+		ssn      string
+		expected string
+	}{
+		{"123-45-6789", "XXX-XX-6789"},
+		{"123 45 6789", "XXX XX 6789"},
+		{"123.45.6789", "XXX.XX.6789"},
+		{"123456789", "XXXXX6789"},
+		{"123-456789", "XXX-XX6789"},
+		// Already masked input is unchanged
+		{"XXX-XX-6789", "XXX-XX-6789"},
+		{"XXX XX 6789", "XXX XX 6789"},
+		{"XXXXX6789", "XXXXX6789"},
+		// Values that are not SSNs are returned as is
+		{"12-345-6789", "12-345-6789"},
+		{"SSN 123-45-6789", "SSN 123-45-6789"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		if got := MaskSSN(tc.ssn); got != tc.expected {
+			t.Errorf("MaskSSN(%q) = %q, expected %q", tc.ssn, got, tc.expected)
+		}
+		if got := MaskSSN(MaskSSN(tc.ssn)); got != tc.expected {
+			t.Errorf("MaskSSN(MaskSSN(%q)) = %q, expected %q", tc.ssn, got, tc.expected)
+		}
+	}
+}
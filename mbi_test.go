@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestValidateMBI tests the Medicare Beneficiary Identifier position rules
+func TestValidateMBI(t *testing.T) {
+	testCases := []struct {
+		mbi   string
+		valid bool
+	}{
+		{"1EG4-TE5-MK73", true},
+		{"1EG4TE5MK73", true},
+		{"1eg4-te5-mk73", true},
+		{"9AA9AA9AA99", true},
+		{"0EG4-TE5-MK73", false}, // First character cannot be 0
+		{"1SG4-TE5-MK73", false}, // S is never used
+		{"1EG4-TE5-MO73", false}, // O is never used
+		{"1EGA-TE5-MK73", false}, // Position 4 must be a digit
+		{"1EG4-5E5-MK73", false}, // Position 5 must be a letter
+		{"1EG4TE5-MK73", false},  // Dashes come in pairs
+		{"1EG4-TE5-MK7", false},  // Too short
+	}
+
+	for _, tc := range testCases {
+		if got := validateMBI(tc.mbi); got != tc.valid {
+			t.Errorf("validateMBI(%q) = %v, expected %v", tc.mbi, got, tc.valid)
+		}
+	}
+}
+
+// TestRedactionEngine_MBI tests that MBI is opt-in
+func TestRedactionEngine_MBI(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Medicare number 1EG4-TE5-MK73 on file", "Medicare number [MBI] on file"},
+		{"MBI 1EG4TE5MK73.", "MBI [MBI]."},
+		{"Not an MBI: 1EG4-TE5-MI73", "Not an MBI: 1EG4-TE5-MI73"},
+	}
+
+	config := DefaultConfig()
+	config.EnabledPatterns["MBI"] = true
+	engine := NewRedactionEngine(config)
+	disabled := NewRedactionEngine(DefaultConfig())
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+		result, _ = disabled.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.input {
+			t.Errorf("Expected MBI to be disabled by default, got %q", result[0].Text)
+		}
+	}
+}
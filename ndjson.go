@@ -0,0 +1,172 @@
+package piiredact
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NDJSONOptions controls RedactNDJSON.
+type NDJSONOptions struct {
+	Fields []string // Dotted paths of the values to redact, e.g. "customer.email"; empty redacts every value
+}
+
+// RedactNDJSON redacts newline-delimited JSON, one object per line, such
+// as a JSONL log or event export.
+//
+// Only string and number values whose path is listed in opts.Fields are
+// redacted, each as its own chunk; everything else, including keys, key
+// order, whitespace and the number formatting of untouched values, is
+// copied through byte for byte. A path is the object keys from the top of
+// the line joined with ".", and array elements take the path of the array,
+// so "orders.card" covers the card of every order in
+// {"orders":[{"card":"..."},{"card":"..."}]}. A number that is redacted
+// becomes a string.
+//
+// The input is read a line at a time, so only one line is ever buffered.
+// Blank lines are copied through. A line that is not valid JSON stops the
+// stream with an error naming the line, since it cannot be redacted field
+// by field. Like ProcessStream, RedactNDJSON reports audit and token
+// sidecar write failures by the next call to Process.
+func (e *RedactionEngine) RedactNDJSON(r io.Reader, w io.Writer, opts NDJSONOptions) error {
+	var fields map[string]bool
+	if len(opts.Fields) > 0 {
+		fields = make(map[string]bool, len(opts.Fields))
+		for _, f := range opts.Fields {
+			fields[f] = true
+		}
+	}
+
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			e.configMu.RLock()
+			out, rerr := e.redactJSONLine(line, fields, n)
+			e.configMu.RUnlock()
+			if rerr != nil {
+				return fmt.Errorf("piiredact: NDJSON line %d: %w", n, rerr)
+			}
+			if _, werr := bw.Write(out); werr != nil {
+				return werr
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// jsonFrame is an object or array being walked by redactJSONLine.
+type jsonFrame struct {
+	object bool   // Whether this is an object; otherwise an array
+	path   string // Path of the object or array itself
+	key    string // Key of the next value, in an object
+	isKey  bool   // Whether the next string token is a key, in an object
+}
+
+// redactJSONLine redacts the targeted values of one line of NDJSON,
+// returning the line with only those values rewritten. A nil fields map
+// targets every value.
+func (e *RedactionEngine) redactJSONLine(line []byte, fields map[string]bool, n int) ([]byte, error) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return line, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	var out []byte
+	var stack []jsonFrame
+	copied := 0 // Bytes of line already written to out
+	for {
+		before := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Work out the path of a value token and settle object keys
+		path := ""
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			path = top.path
+			if top.object {
+				if _, ok := tok.(json.Delim); !ok && top.isKey {
+					top.key, top.isKey = tok.(string), false
+					continue
+				}
+				path = joinPath(top.path, top.key)
+				top.isKey = true
+			}
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				stack = append(stack, jsonFrame{object: t == '{', path: path, isKey: true})
+			default:
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		case string, json.Number:
+			if fields != nil && !fields[path] {
+				continue
+			}
+		default:
+			continue
+		}
+
+		// Locate the raw value: only blanks, ':' and ',' precede it
+		end := int(dec.InputOffset())
+		start := before + bytes.IndexFunc(line[before:end], func(r rune) bool {
+			return r != ' ' && r != '\t' && r != '\r' && r != '\n' && r != ':' && r != ','
+		})
+
+		text := fmt.Sprint(tok)
+		redacted := e.redactChunk(Chunk{UUID: fmt.Sprintf("line-%d:%s", n, path), Text: text}).Text
+		if redacted == text {
+			continue
+		}
+		out = append(out, line[copied:start]...)
+		out = appendJSONString(out, redacted)
+		copied = end
+	}
+
+	if out == nil {
+		return line, nil
+	}
+	return append(out, line[copied:]...), nil
+}
+
+// joinPath appends key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// appendJSONString appends s as a JSON string, without escaping HTML
+// characters so labels such as "<SSN>" stay readable.
+func appendJSONString(out []byte, s string) []byte {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // Encoding a string cannot fail
+	return append(out, strings.TrimSuffix(b.String(), "\n")...)
+}
@@ -0,0 +1,72 @@
+package piiredact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestRedactionEngine_RedactNDJSON tests redacting targeted fields line by line
+func TestRedactionEngine_RedactNDJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"401-23-4567","customer":{"name":"Jane","email":"jane@example.com","ssn":"401-23-4567"},"note":"call 404-555-1212"}`,
+		``,
+		`{"note": "none", "customer": {"ssn": 401234567, "email": null}, "orders": [{"card": "4111 1111 1111 1111"}, {"card": "4111111111111111", "qty": 2}]}`,
+		`{"customer":{"email":"a@example.com"}}`,
+	}, "\n") + "\n"
+	expected := strings.Join([]string{
+		`{"id":"401-23-4567","customer":{"name":"Jane","email":"[EMAIL]","ssn":"[SSN]"},"note":"call [PHONE]"}`,
+		``,
+		`{"note": "none", "customer": {"ssn": "[SSN]", "email": null}, "orders": [{"card": "[CC]"}, {"card": "[CC]", "qty": 2}]}`,
+		`{"customer":{"email":"[EMAIL]"}}`,
+	}, "\n") + "\n"
+
+	engine := NewRedactionEngine(DefaultConfig())
+	var out bytes.Buffer
+	opts := NDJSONOptions{Fields: []string{"customer.email", "customer.ssn", "note", "orders.card"}}
+	if err := engine.RedactNDJSON(strings.NewReader(input), &out, opts); err != nil {
+		t.Fatalf("RedactNDJSON returned error: %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, out.String())
+	}
+}
+
+// TestRedactionEngine_RedactNDJSONAllFields tests that no Fields redacts every value
+func TestRedactionEngine_RedactNDJSONAllFields(t *testing.T) {
+	input := "{\"a\":\"401-23-4567\",\"b\":[\"jane@example.com\",7],\"401-23-4567\":true}\r\n{\"c\":\"<ok>\"}"
+	expected := "{\"a\":\"<SSN>\",\"b\":[\"<EMAIL>\",7],\"401-23-4567\":true}\r\n{\"c\":\"<ok>\"}"
+
+	config := DefaultConfig()
+	config.RedactionFormat = "<%s>"
+	engine := NewRedactionEngine(config)
+
+	var out bytes.Buffer
+	if err := engine.RedactNDJSON(iotest.OneByteReader(strings.NewReader(input)), &out, NDJSONOptions{}); err != nil {
+		t.Fatalf("RedactNDJSON returned error: %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, out.String())
+	}
+}
+
+// TestRedactionEngine_RedactNDJSONInvalid tests that a malformed line stops the stream
+func TestRedactionEngine_RedactNDJSONInvalid(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	input := "{\"a\":\"x\"}\n{\"a\": 401-23-4567}\n"
+
+	var out bytes.Buffer
+	err := engine.RedactNDJSON(strings.NewReader(input), &out, NDJSONOptions{})
+	if err == nil || !strings.Contains(err.Error(), "NDJSON line 2") {
+		t.Errorf("Expected an error naming line 2, got %v", err)
+	}
+	if strings.Contains(out.String(), "401-23-4567") {
+		t.Errorf("Malformed line was written: %q", out.String())
+	}
+
+	// Write errors are returned
+	if err := engine.RedactNDJSON(strings.NewReader(`{"a":"x"}`), failingWriter{}, NDJSONOptions{}); err == nil {
+		t.Error("Expected the write error, got nil")
+	}
+}
@@ -0,0 +1,177 @@
+package piiredact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NERClient is the interface to an external named-entity recognition or PII
+// service. Implementations return detections with byte offsets into text
+// and must honour ctx cancellation.
+type NERClient interface {
+	Recognize(ctx context.Context, text string) ([]Detection, error)
+}
+
+// ErrDetectorUnavailable is returned by NERDetector.Detect while the
+// detector is cooling down after a failed call.
+var ErrDetectorUnavailable = errors.New("piiredact: detector unavailable")
+
+// NERDetector is a Detector backed by an external NER service, for entities
+// such as PERSON or LOCATION that patterns miss.
+//
+// Each call is bounded by Timeout. When a call fails, the detector stops
+// calling the service for Cooldown and returns ErrDetectorUnavailable in
+// the meantime, so an outage costs one timeout per cooldown period rather
+// than one per chunk. Every chunk scanned during the outage is treated as
+// a detector failure: redacted by the engine's patterns alone, or withheld
+// with Config.FailClosed.
+type NERDetector struct {
+	Client   NERClient     // Service client
+	Timeout  time.Duration // Deadline for each call (default 2s)
+	Cooldown time.Duration // How long to skip the service after a failure (default 30s)
+	Entities []string      // Entity types to keep, e.g. "PERSON"; empty keeps all
+
+	mu        sync.Mutex
+	downUntil time.Time // Calls are skipped until this time after a failure
+}
+
+// NewNERDetector returns an NERDetector for client with default timeouts.
+func NewNERDetector(client NERClient) *NERDetector {
+	return &NERDetector{
+		Client:   client,
+		Timeout:  2 * time.Second,
+		Cooldown: 30 * time.Second,
+	}
+}
+
+// Detect implements Detector.
+func (d *NERDetector) Detect(text string) ([]Detection, error) {
+	d.mu.Lock()
+	down := time.Now().Before(d.downUntil)
+	d.mu.Unlock()
+	if down {
+		return nil, ErrDetectorUnavailable
+	}
+
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	detections, err := d.Client.Recognize(ctx, text)
+	if err != nil {
+		cooldown := d.Cooldown
+		if cooldown <= 0 {
+			cooldown = 30 * time.Second
+		}
+		d.mu.Lock()
+		d.downUntil = time.Now().Add(cooldown)
+		d.mu.Unlock()
+		return nil, fmt.Errorf("piiredact: NER service: %w", err)
+	}
+
+	if len(d.Entities) == 0 {
+		return detections, nil
+	}
+	keep := make(map[string]bool, len(d.Entities))
+	for _, name := range d.Entities {
+		keep[name] = true
+	}
+	var filtered []Detection
+	for _, det := range detections {
+		if keep[det.PatternName] {
+			filtered = append(filtered, det)
+		}
+	}
+	return filtered, nil
+}
+
+// HTTPNERClient is an NERClient for services speaking a simple JSON
+// protocol. It POSTs
+//
+//	{"text": "Jane lives in Paris"}
+//
+// to URL and expects a 2xx response of the form
+//
+//	{"entities": [{"type": "PERSON", "start": 0, "end": 4}, ...]}
+//
+// where start and end are Unicode code point offsets, as produced by most
+// Python NER libraries. They are converted to byte offsets.
+type HTTPNERClient struct {
+	URL    string       // Endpoint of the service
+	Client *http.Client // HTTP client to use (default http.DefaultClient)
+}
+
+// nerRequest and nerResponse are the HTTPNERClient wire format.
+type nerRequest struct {
+	Text string `json:"text"`
+}
+
+type nerResponse struct {
+	Entities []struct {
+		Type  string `json:"type"`
+		Start int    `json:"start"`
+		End   int    `json:"end"`
+	} `json:"entities"`
+}
+
+// Recognize implements NERClient.
+func (c *HTTPNERClient) Recognize(ctx context.Context, text string) ([]Detection, error) {
+	body, err := json.Marshal(nerRequest{Text: text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parsed nerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	// Map code point offsets to byte offsets
+	byteOffset := make([]int, 0, len(text)+1)
+	for i := range text {
+		byteOffset = append(byteOffset, i)
+	}
+	byteOffset = append(byteOffset, len(text))
+
+	detections := make([]Detection, 0, len(parsed.Entities))
+	for _, ent := range parsed.Entities {
+		if ent.Start < 0 || ent.End >= len(byteOffset) || ent.Start >= ent.End {
+			continue
+		}
+		start, end := byteOffset[ent.Start], byteOffset[ent.End]
+		detections = append(detections, Detection{
+			PatternName: ent.Type,
+			Start:       start,
+			End:         end,
+			Value:       text[start:end],
+		})
+	}
+	return detections, nil
+}
@@ -0,0 +1,176 @@
+package piiredact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mockNERClient finds fixed words and can be made to fail
+type mockNERClient struct {
+	entities map[string]string // Word to entity type
+	err      error
+	calls    atomic.Int32
+}
+
+func (m *mockNERClient) Recognize(ctx context.Context, text string) ([]Detection, error) {
+	m.calls.Add(1)
+	if m.err != nil {
+		return nil, m.err
+	}
+	var detections []Detection
+	for word, typ := range m.entities {
+		if i := strings.Index(text, word); i >= 0 {
+			detections = append(detections, Detection{PatternName: typ, Start: i, End: i + len(word), Value: word})
+		}
+	}
+	return detections, nil
+}
+
+// TestRedactionEngine_NERDetector tests merging external detections with patterns
+func TestRedactionEngine_NERDetector(t *testing.T) {
+	client := &mockNERClient{entities: map[string]string{"Jane Doe": "PERSON", "Paris": "LOCATION"}}
+	config := DefaultConfig()
+	config.Detectors = []Detector{NewNERDetector(client)}
+	engine := NewRedactionEngine(config)
+
+	result, err := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "Jane Doe from Paris, SSN 123-45-6789"}})
+	if err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+	if expected := "[PERSON] from [LOCATION], SSN [SSN]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+	if metrics := engine.GetMetrics(); metrics.RedactedItems["PERSON"] != 1 {
+		t.Errorf("Expected 1 PERSON redaction, got %d", metrics.RedactedItems["PERSON"])
+	}
+
+	// Entities limits the kept types
+	detector := NewNERDetector(client)
+	detector.Entities = []string{"PERSON"}
+	config.Detectors = []Detector{detector}
+	engine = NewRedactionEngine(config)
+	result, _ = engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "Jane Doe from Paris"}})
+	if expected := "[PERSON] from Paris"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_NERDetectorDown tests falling back to patterns while the service is down
+func TestRedactionEngine_NERDetectorDown(t *testing.T) {
+	client := &mockNERClient{err: errors.New("connection refused")}
+	config := DefaultConfig()
+	config.MaxConcurrency = 1
+	config.Detectors = []Detector{NewNERDetector(client)}
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Jane Doe, SSN 123-45-6789"},
+		{UUID: "id2", Speaker: "A", Text: "call 404-555-1212"},
+	}
+	result, err := engine.Process(chunks)
+	if result[0].Text != "Jane Doe, SSN [SSN]" || result[1].Text != "call [PHONE]" {
+		t.Errorf("Patterns not applied during outage: %+v", result)
+	}
+
+	// The failed call and the call skipped during the cooldown are both reported
+	var processErr *ProcessError
+	if !errors.As(err, &processErr) {
+		t.Fatalf("Expected a *ProcessError, got %v", err)
+	}
+	if len(processErr.Chunks) != 2 || processErr.Chunks[0].UUID != "id1" || processErr.Chunks[1].UUID != "id2" ||
+		processErr.Chunks[0].Kind != ChunkDetectorFailed || processErr.Chunks[1].Kind != ChunkDetectorFailed {
+		t.Errorf("Unexpected chunk problems: %+v", processErr.Chunks)
+	}
+
+	// The cooldown stops calls after the first failure
+	if calls := client.calls.Load(); calls != 1 {
+		t.Errorf("Expected 1 call during cooldown, got %d", calls)
+	}
+
+	// Skipped calls say why
+	detector := NewNERDetector(client)
+	detector.Detect("Jane Doe")
+	if _, err := detector.Detect("Jane Doe"); !errors.Is(err, ErrDetectorUnavailable) {
+		t.Errorf("Expected ErrDetectorUnavailable during the cooldown, got %v", err)
+	}
+}
+
+// TestHTTPNERClient tests the JSON protocol, code point offsets and timeouts
+func TestHTTPNERClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Text, "slow") {
+			time.Sleep(200 * time.Millisecond)
+		}
+		// Offsets are code points: "José" starts at 5 in "Hola José"
+		w.Write([]byte(`{"entities":[{"type":"PERSON","start":5,"end":9}]}`))
+	}))
+	defer server.Close()
+
+	detector := NewNERDetector(&HTTPNERClient{URL: server.URL})
+	detector.Timeout = 50 * time.Millisecond
+	config := DefaultConfig()
+	config.Detectors = []Detector{detector}
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "Hola José"}})
+	if expected := "Hola [PERSON]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+
+	if _, err := detector.Detect("slow José"); err == nil {
+		t.Error("Expected a timeout error from a slow service")
+	}
+}
+
+// TestRedactionEngine_NERDetectorFailClosed tests that FailClosed withholds
+// every chunk scanned while the service is down, not only the first
+func TestRedactionEngine_NERDetectorFailClosed(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxConcurrency = 1
+	config.Detectors = []Detector{NewNERDetector(&HTTPNERClient{URL: server.URL})}
+	config.FailClosed = true
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Jane Roe lives in Paris"},
+		{UUID: "id2", Speaker: "A", Text: "Jane Roe lives in Paris"},
+		{UUID: "id3", Speaker: "A", Text: "Jane Roe lives in Paris"},
+	}
+	result, err := engine.Process(chunks)
+	for i, c := range result {
+		if c.Text != "[REDACTED]" {
+			t.Errorf("Chunk %d: expected [REDACTED], got %q", i, c.Text)
+		}
+	}
+
+	var processErr *ProcessError
+	if !errors.As(err, &processErr) || len(processErr.Chunks) != len(chunks) {
+		t.Fatalf("Expected a ProcessError for every chunk, got %v", err)
+	}
+	for _, p := range processErr.Chunks {
+		if p.Kind != ChunkDetectorFailed {
+			t.Errorf("Chunk %s: expected ChunkDetectorFailed, got %v", p.UUID, p.Kind)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected 1 request before the cooldown, got %d", n)
+	}
+}
@@ -0,0 +1,63 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestValidateNHS tests the NHS number mod-11 check digit
+func TestValidateNHS(t *testing.T) {
+	testCases := []struct {
+		nhs   string
+		valid bool
+	}{
+		{"943 476 5919", true},
+		{"9434765919", true},
+		{"401 023 2137", true},
+		{"401 000 0090", true},  // Remainder 0 gives check digit 11, written as 0
+		{"943 476 5918", false}, // Wrong check digit
+		{"123 456 7890", false}, // Check digit would be 10
+		{"123 456 7891", false}, // Check digit would be 10
+		{"943 476 591", false},  // Too short
+		{"943-476-5919", false}, // Only spaces are allowed
+	}
+
+	for _, tc := range testCases {
+		if got := validateNHS(tc.nhs); got != tc.valid {
+			t.Errorf("validateNHS(%q) = %v, expected %v", tc.nhs, got, tc.valid)
+		}
+	}
+
+	if valid, err := ValidateValue("NHS", "943 476 5919"); err != nil || !valid {
+		t.Errorf("ValidateValue(NHS) = %v, %v, expected true", valid, err)
+	}
+}
+
+// TestRedactionEngine_NHS tests that NHS is opt-in and outranks PHONE
+func TestRedactionEngine_NHS(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		disabled string
+	}{
+		{"NHS number 943 476 5919 on file", "NHS number [NHS] on file", "NHS number [PHONE] on file"},
+		{"NHS 9434765919.", "NHS [NHS].", "NHS [PHONE]."},
+		{"Not an NHS number: 943 476 5918", "Not an NHS number: [PHONE]", "Not an NHS number: [PHONE]"},
+		{"Reference 1234567890", "Reference [PHONE]", "Reference [PHONE]"},
+	}
+
+	config := DefaultConfig()
+	config.EnabledPatterns["NHS"] = true
+	engine := NewRedactionEngine(config)
+	disabled := NewRedactionEngine(DefaultConfig())
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+		result, _ = disabled.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.disabled {
+			t.Errorf("Expected NHS to be disabled by default: expected %q, got %q", tc.disabled, result[0].Text)
+		}
+	}
+}
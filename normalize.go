@@ -0,0 +1,104 @@
+package piiredact
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Whitespace normalization.
+//
+// Users sometimes type or dictate identifiers one character at a time, as
+// in "4 0 1 - 2 3 - 4 5 6 7", which no pattern matches. With
+// Config.NormalizeWhitespace the engine scans a normalized copy of the text
+// with those spaces removed, then maps each match back to the span it
+// covers in the original text. Everything else in the text is unchanged by
+// normalization, so other values are found as before. Offsets reported
+// anywhere outside detection, including RedactDetailed details and audit
+// records, are therefore always in original-text coordinates, and the
+// whole spaced-out span is redacted.
+
+// collapseSpacedDigits removes the whitespace between single-character
+// digits and dashes that are not part of a longer word or number, so
+// "4 0 1 - 2 3" becomes "401-23" while "555 123 4567" is left as is. It
+// returns the normalized text and, for each of its bytes, the offset of
+// that byte in text; origin is nil if nothing was removed.
+func collapseSpacedDigits(text string) (normalized string, origin []int) {
+	// Find the single-character tokens: a digit or dash that is not part of
+	// a longer word or number
+	single := func(i int) bool {
+		if i < 0 || i >= len(text) || !(text[i] >= '0' && text[i] <= '9' || text[i] == '-') {
+			return false
+		}
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		next, _ := utf8.DecodeRuneInString(text[i+1:])
+		return (i == 0 || !isWordRune(prev)) && (i+1 == len(text) || !isWordRune(next))
+	}
+
+	buf := make([]byte, 0, len(text))
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !unicode.IsSpace(r) || !single(i-1) {
+			buf = append(buf, text[i:i+size]...)
+			origin = appendOffsets(origin, i, size)
+			i += size
+			continue
+		}
+
+		// Skip the run of whitespace only if another single character follows
+		j := i
+		for j < len(text) {
+			r, size := utf8.DecodeRuneInString(text[j:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			j += size
+		}
+		if !single(j) {
+			buf = append(buf, text[i:j]...)
+			origin = appendOffsets(origin, i, j-i)
+		}
+		i = j
+	}
+
+	if len(buf) == len(text) {
+		return text, nil
+	}
+	return string(buf), origin
+}
+
+// appendOffsets appends the offsets of the size bytes starting at i.
+func appendOffsets(origin []int, i, size int) []int {
+	for k := i; k < i+size; k++ {
+		origin = append(origin, k)
+	}
+	return origin
+}
+
+// findNormalizedMatches returns findMatches(text, skip), scanning the
+// normalized text instead when Config.PDFLayout or
+// Config.NormalizeWhitespace is set, in that order. Context checks and
+// OnMatch then see the normalized text and its offsets. Matches in the
+// normalized text are mapped back to original offsets; a spaced-out value
+// then spans the whitespace between its characters.
+func (e *RedactionEngine) findNormalizedMatches(text string, skip []bool) ([]match, error) {
+	normalized := text
+	var origin []int
+	if e.config.PDFLayout {
+		normalized, origin = normalizeLayout(normalized)
+	}
+	if e.config.NormalizeWhitespace {
+		var collapsed []int
+		normalized, collapsed = collapseSpacedDigits(normalized)
+		origin = composeOrigins(origin, collapsed)
+	}
+	if origin == nil {
+		return e.findMatches(text, skip)
+	}
+
+	matches, err := e.findMatches(normalized, skip)
+	for i, m := range matches {
+		matches[i].start = origin[m.start]
+		matches[i].end = origin[m.end-1] + 1
+	}
+	return matches, err
+}
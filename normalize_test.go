@@ -0,0 +1,76 @@
+package piiredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCollapseSpacedDigits tests removing spaces between spaced-out characters
+func TestCollapseSpacedDigits(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"SSN 4 0 1 - 2 3 - 4 5 6 7 ok", "SSN 401-23-4567 ok"},
+		{"4  0\t1", "401"},
+		{"call 555 123 4567", "call 555 123 4567"},
+		{"room 4 on floor 2", "room 4 on floor 2"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		normalized, origin := collapseSpacedDigits(tc.text)
+		if normalized != tc.expected {
+			t.Errorf("collapseSpacedDigits(%q) = %q, expected %q", tc.text, normalized, tc.expected)
+		}
+		if (origin == nil) != (normalized == tc.text) {
+			t.Errorf("collapseSpacedDigits(%q): unexpected origin %v", tc.text, origin)
+		}
+		for i, o := range origin {
+			if tc.text[o] != normalized[i] {
+				t.Errorf("collapseSpacedDigits(%q): byte %d maps to %d", tc.text, i, o)
+			}
+		}
+	}
+}
+
+// TestRedactionEngine_NormalizeWhitespace tests that spaced-out values are
+// redacted and reported in original-text offsets
+func TestRedactionEngine_NormalizeWhitespace(t *testing.T) {
+	config := DefaultConfig()
+	config.NormalizeWhitespace = true
+	engine := NewRedactionEngine(config)
+
+	input := "My SSN is 4 0 1 - 2 3 - 4 5 6 7, email jane@example.com, ssn 5 2 3 4 5 6 7 8 9"
+	redacted, details := engine.RedactDetailed(input)
+	if expected := "My SSN is [SSN], email [EMAIL], ssn [SSN]"; redacted != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, redacted)
+	}
+
+	var got []Detection
+	for _, d := range details {
+		got = append(got, d.Detection)
+	}
+	expected := []Detection{
+		{PatternName: "SSN", Start: 10, End: 31, Value: "4 0 1 - 2 3 - 4 5 6 7", Confidence: 1, Sensitivity: SensitivityHigh},
+		{PatternName: "EMAIL", Start: 39, End: 55, Value: "jane@example.com", Confidence: 1, Sensitivity: SensitivityMedium},
+		{PatternName: "SSN", Start: 61, End: 78, Value: "5 2 3 4 5 6 7 8 9", Confidence: 1, Sensitivity: SensitivityHigh},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Detections = %+v, expected %+v", got, expected)
+	}
+	for _, d := range got {
+		if input[d.Start:d.End] != d.Value {
+			t.Errorf("Offsets %d-%d do not cover %q", d.Start, d.End, d.Value)
+		}
+	}
+	if items := engine.GetMetrics().RedactedItems["SSN"]; items != 2 {
+		t.Errorf("Expected 2 SSN redactions, got %d", items)
+	}
+
+	// Without the option spaced-out values are not detected
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "My SSN is 4 0 1 - 2 3 - 4 5 6 7, email [EMAIL], ssn 5 2 3 4 5 6 7 8 9"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
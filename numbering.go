@@ -0,0 +1,63 @@
+package piiredact
+
+import (
+	"strconv"
+	"sync"
+)
+
+// NumberingScope selects whether and how labels are numbered per type.
+type NumberingScope int
+
+const (
+	// NumberingOff labels every value of a type the same, e.g. "[SSN]".
+	NumberingOff NumberingScope = iota
+
+	// NumberingChunk numbers distinct values of each type within a chunk,
+	// e.g. "[SSN_1]" and "[SSN_2]"; numbering restarts in every chunk.
+	NumberingChunk
+
+	// NumberingSession numbers distinct values of each type for the
+	// lifetime of the engine, so a value keeps its number across chunks.
+	// With concurrent processing the order in which numbers are assigned
+	// follows processing order, not input order.
+	NumberingSession
+)
+
+// labelNumbers assigns each distinct value of a type the next index.
+type labelNumbers struct {
+	mu     sync.Mutex
+	byName map[string]map[string]int // Pattern name to value to index
+}
+
+// newLabelNumbers creates an empty numbering.
+func newLabelNumbers() *labelNumbers {
+	return &labelNumbers{byName: make(map[string]map[string]int)}
+}
+
+// label returns name with the value's index appended, e.g. "SSN_2". The
+// same value always gets the same index. It returns name unchanged on a nil
+// numbering.
+func (n *labelNumbers) label(name, value string) string {
+	if n == nil {
+		return name
+	}
+	return name + "_" + strconv.Itoa(n.index(name, value))
+}
+
+// index returns the value's index among the distinct values of its type,
+// assigning the next one to a new value.
+func (n *labelNumbers) index(name, value string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	values := n.byName[name]
+	if values == nil {
+		values = make(map[string]int)
+		n.byName[name] = values
+	}
+	index, ok := values[value]
+	if !ok {
+		index = len(values) + 1
+		values[value] = index
+	}
+	return index
+}
@@ -0,0 +1,47 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_Numbering tests numbered labels and their scopes
+func TestRedactionEngine_Numbering(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789, spouse 401-23-4567, again 123-45-6789"},
+		{UUID: "id2", Speaker: "B", Text: "new 234-56-7890 and old 401-23-4567, email jane@example.com"},
+	}
+
+	testCases := []struct {
+		scope    NumberingScope
+		expected []string
+	}{
+		{NumberingOff, []string{
+			"SSN [SSN], spouse [SSN], again [SSN]",
+			"new [SSN] and old [SSN], email [EMAIL]",
+		}},
+		// Numbering restarts in every chunk
+		{NumberingChunk, []string{
+			"SSN [SSN_1], spouse [SSN_2], again [SSN_1]",
+			"new [SSN_1] and old [SSN_2], email [EMAIL_1]",
+		}},
+		// A value keeps its number across chunks
+		{NumberingSession, []string{
+			"SSN [SSN_1], spouse [SSN_2], again [SSN_1]",
+			"new [SSN_3] and old [SSN_2], email [EMAIL_1]",
+		}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.MaxConcurrency = 1 // Session numbers follow processing order
+		config.Numbering = tc.scope
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process(chunks)
+		for i, expected := range tc.expected {
+			if result[i].Text != expected {
+				t.Errorf("Scope %d, chunk %d:\nExpected: %s\nGot: %s", tc.scope, i, expected, result[i].Text)
+			}
+		}
+	}
+}
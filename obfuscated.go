@@ -0,0 +1,97 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Obfuscated email addresses.
+//
+// People write addresses as "jane [at] example [dot] com" or "jane at
+// example dot com" to keep them from scrapers. With
+// Config.ObfuscatedEmails the engine looks for addresses spelled this way,
+// replaces each "at" and "dot" with "@" and ".", and redacts the original
+// span as EMAIL if the result is an address EMAIL would match.
+//
+// The words "at" and "dot" are common in ordinary speech, so the option is
+// off by default. A domain needs at least one dot and a top-level domain
+// of two or more letters, but "meet me at home dot com" is still read as
+// an address.
+
+// Obfuscated separators: "at" or "dot" in brackets, or spaced out as a
+// word. A real "@" or "." also counts, so partly obfuscated addresses such
+// as "jane@example [dot] com" are found.
+const (
+	obfuscatedAt  = `(?:\s*[\[\(\{<]\s*at\s*[\]\)\}>]\s*|\s+at\s+|@)`
+	obfuscatedDot = `(?:\s*[\[\(\{<]\s*dot\s*[\]\)\}>]\s*|\s+dot\s+|\.)`
+)
+
+// obfuscatedEmailRegex matches an address with obfuscated separators.
+var obfuscatedEmailRegex = regexp.MustCompile(`(?i)\b[a-z0-9_%+-]+(?:` + obfuscatedDot + `[a-z0-9_%+-]+)*` +
+	obfuscatedAt + `[a-z0-9-]+(?:` + obfuscatedDot + `[a-z0-9-]+)+\b`)
+
+// obfuscatedSeparatorRegex matches one obfuscated separator, capturing
+// its word.
+var obfuscatedSeparatorRegex = regexp.MustCompile(`(?i)\s*[\[\(\{<]\s*(at|dot)\s*[\]\)\}>]\s*|\s+(at|dot)\s+`)
+
+// plainEmailRegex matches a whole address as EMAIL would.
+var plainEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// deobfuscateEmail replaces the obfuscated separators in value with "@"
+// and ".".
+func deobfuscateEmail(value string) string {
+	return obfuscatedSeparatorRegex.ReplaceAllStringFunc(value, func(sep string) string {
+		if strings.Contains(strings.ToLower(sep), "dot") {
+			return "."
+		}
+		return "@"
+	})
+}
+
+// followedByAtRegex matches an obfuscated "at" and the start of a domain.
+var followedByAtRegex = regexp.MustCompile(`(?i)^` + obfuscatedAt + `[a-z0-9-]`)
+
+// findObfuscatedEmails returns the spans of obfuscated addresses in text.
+// Plain addresses are left to EMAIL.
+//
+// A match followed by another "at" started too early, as "me at john dot
+// smith" does in "me at john dot smith at example dot com", so the search
+// resumes after its first word.
+func findObfuscatedEmails(text string) [][]int {
+	var spans [][]int
+	for pos := 0; pos < len(text); {
+		loc := obfuscatedEmailRegex.FindStringIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		if followedByAtRegex.MatchString(text[end:]) {
+			pos = start + strings.IndexFunc(text[start:], func(r rune) bool { return !isLocalPartRune(r) })
+			continue
+		}
+		pos = end
+
+		value := text[start:end]
+		email := deobfuscateEmail(value)
+		if email != value && strings.Count(email, "@") == 1 && plainEmailRegex.MatchString(email) {
+			spans = append(spans, []int{start, end})
+		}
+	}
+	return spans
+}
+
+// isLocalPartRune reports whether r can appear in a word of an obfuscated
+// address's local part.
+func isLocalPartRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_%+-", r)
+}
+
+// obfuscatedEmailPattern detects obfuscated addresses when
+// Config.ObfuscatedEmails is set.
+var obfuscatedEmailPattern = PatternDef{
+	Name:        "EMAIL",
+	Description: "Email address",
+	Regex:       obfuscatedEmailRegex,
+	find:        findObfuscatedEmails,
+	Sensitivity: SensitivityMedium,
+}
@@ -0,0 +1,70 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_ObfuscatedEmails tests redacting addresses with spelled-out separators
+func TestRedactionEngine_ObfuscatedEmails(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"write to jane [at] example [dot] com today", "write to [EMAIL] today"},
+		{"jane(at)example(dot)co(dot)uk", "[EMAIL]"},
+		{"reach me at john dot smith at mail dot example dot org", "reach me at [EMAIL]"},
+		{"it's JANE AT EXAMPLE DOT COM", "it's [EMAIL]"},
+		{"jane {at} example {dot} com", "[EMAIL]"},
+		{"jane < at > example < dot > com", "[EMAIL]"},
+		{"partly jane@example [dot] com", "partly [EMAIL]"},
+		{"and jane [at] example.com", "and [EMAIL]"},
+		// Plain addresses are still redacted by EMAIL
+		{"plain jane@example.com", "plain [EMAIL]"},
+		// Everyday uses of "at" and "dot" are left alone
+		{"see you at noon", "see you at noon"},
+		{"I'm at the office", "I'm at the office"},
+		{"connect the dots at home", "connect the dots at home"},
+		{"jane at example dot c", "jane at example dot c"},
+		{"put a dot at the end", "put a dot at the end"},
+	}
+
+	config := DefaultConfig()
+	config.ObfuscatedEmails = true
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without the option, or with EMAIL disabled, obfuscated addresses are left alone
+	input := "write to jane [at] example [dot] com"
+	config.EnabledPatterns = map[string]bool{"SSN": true}
+	for _, engine := range []*RedactionEngine{NewRedactionEngine(DefaultConfig()), NewRedactionEngine(config)} {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != input {
+			t.Errorf("Expected %q unchanged, got %q", input, result[0].Text)
+		}
+	}
+}
+
+// TestDeobfuscateEmail tests rewriting obfuscated separators
+func TestDeobfuscateEmail(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"jane [at] example [dot] com", "jane@example.com"},
+		{"jane AT example DOT com", "jane@example.com"},
+		{"john dot smith(at)mail.example.org", "john.smith@mail.example.org"},
+		{"jane@example.com", "jane@example.com"},
+	}
+
+	for _, tc := range testCases {
+		if got := deobfuscateEmail(tc.value); got != tc.expected {
+			t.Errorf("deobfuscateEmail(%q) = %q, expected %q", tc.value, got, tc.expected)
+		}
+	}
+}
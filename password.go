@@ -0,0 +1,62 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Inline passwords.
+//
+// Passwords have no structure to match, so the PASSWORD pattern matches any
+// token and relies entirely on its ContextValidate: a token is redacted only
+// when it directly follows a cue phrase such as "password is" or "pwd:".
+
+// passwordTokenRegex matches a whitespace-delimited token.
+var passwordTokenRegex = regexp.MustCompile(`\S+`)
+
+// passwordTrailing is the sentence punctuation dropped from the end of a
+// password token.
+const passwordTrailing = `.,;!?"')`
+
+// passwordCues are the lowercase words that introduce a password. The cue
+// must be followed by one of passwordConnectors before the token, except
+// that a bare "passcode" is enough when the token contains a digit, as in
+// "passcode 4821".
+var passwordCues = []string{"password", "passwd", "pwd", "passcode"}
+
+// passwordConnectors may separate a password cue from the password.
+var passwordConnectors = []string{":", "=", "is"}
+
+// afterPasswordCue reports whether the match at start directly follows one
+// of passwordCues.
+func afterPasswordCue(text string, start, end int) bool {
+	cue, connected, ok := cueBefore(text, start, passwordCues, passwordConnectors)
+	return ok && (connected || cue == "passcode" && strings.ContainsAny(text[start:end], "0123456789"))
+}
+
+// findPasswords returns the span of each candidate password in text: every
+// whitespace-delimited token, less trailing sentence punctuation. A token
+// that starts with a cue and a separator, as in "pwd:hunter2" or
+// "password=x=y", is split at that first separator only, so the rest of
+// the token is redacted whatever ":" or "=" it contains. afterPasswordCue
+// then keeps the candidates that directly follow a cue.
+func findPasswords(text string) [][]int {
+	var spans [][]int
+	for _, m := range passwordTokenRegex.FindAllStringIndex(text, -1) {
+		start, end := m[0], m[1]
+		if i := strings.IndexAny(text[start:end], ":="); i >= 0 {
+			prefix := strings.ToLower(text[start : start+i])
+			for _, cue := range passwordCues {
+				if strings.HasSuffix(prefix, cue) {
+					start += i + 1
+					break
+				}
+			}
+		}
+		end = start + len(strings.TrimRight(text[start:end], passwordTrailing))
+		if end > start {
+			spans = append(spans, []int{start, end})
+		}
+	}
+	return spans
+}
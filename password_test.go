@@ -0,0 +1,51 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_Password tests redacting passwords after cue phrases
+func TestRedactionEngine_Password(t *testing.T) {
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"PASSWORD": true}
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"my password is hunter2", "my password is [PASSWORD]"},
+		{"My Password is hunter2.", "My Password is [PASSWORD]."},
+		{"password: S3cr3t!x, thanks", "password: [PASSWORD], thanks"},
+		{"pwd:hunter2", "pwd:[PASSWORD]"},
+		{"set PWD = tr0ub4dor", "set PWD = [PASSWORD]"},
+		// Separators inside the password are part of it
+		{"password is ab:cd", "password is [PASSWORD]"},
+		{"pwd=x=y", "pwd=[PASSWORD]"},
+		{"password: a=b:c.", "password: [PASSWORD]."},
+		{"Password:key:value, thanks", "Password:[PASSWORD], thanks"},
+		{"the passcode 4821 works", "the passcode [PASSWORD] works"},
+		{"Passcode is 4821", "Passcode is [PASSWORD]"},
+		// Only the token right after the cue is redacted
+		{"password is hunter2 and my name is Bob", "password is [PASSWORD] and my name is Bob"},
+		// Mentions of passwords without a value are left alone
+		{"I need a password reset", "I need a password reset"},
+		{"the password expired", "the password expired"},
+		{"the passcode expired", "the passcode expired"},
+		{"mypwd:hunter2", "mypwd:hunter2"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// The pattern is disabled by default
+	input := "my password is hunter2"
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if result[0].Text != input {
+		t.Errorf("Expected PASSWORD to be opt-in, got: %s", result[0].Text)
+	}
+}
@@ -0,0 +1,48 @@
+package piiredact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// homePathRegex matches a home directory path, such as "/home/jsmith",
+// "/Users/jsmith" or "C:\Users\jsmith", capturing the username segment.
+// The username stops at punctuation that usually closes the path, such as
+// the ")" of "(/home/jsmith)".
+var homePathRegex = regexp.MustCompile(`(?i)(?:\b[a-z]:)?[/\\](?:home|users)[/\\]([^/\\\s"'<>|:*?)\],;]+)`)
+
+// sharedProfiles are directories under a home root that belong to no user.
+var sharedProfiles = map[string]bool{
+	"public": true, "default": true, "default user": true, "all users": true, "shared": true,
+}
+
+// findHomeUsername returns the span of the username in each home directory
+// path in text, so only that segment is redacted and the rest of the path
+// stays readable: "/home/jsmith/.ssh" becomes "/home/[USERNAME]/.ssh".
+//
+// The path must start the token it is in, or follow "file://", so the
+// "/home/" of a URL such as "https://example.com/home/about" is not taken
+// for a home directory while "file:///home/jsmith" is. A username ends at
+// whitespace, so only "Bob" is found in an unquoted "C:\Users\Bob Smith",
+// and a trailing "." is taken for the end of a sentence.
+func findHomeUsername(text string) [][]int {
+	var spans [][]int
+	for _, m := range homePathRegex.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > 0 && !strings.ContainsRune(" \t\r\n\"'=(`[<,;", rune(text[m[0]-1])) && !afterFileScheme(text, m[0]) {
+			continue
+		}
+		end := m[2] + len(strings.TrimRight(text[m[2]:m[3]], "."))
+		if end == m[2] || sharedProfiles[strings.ToLower(text[m[2]:end])] {
+			continue
+		}
+		spans = append(spans, []int{m[2], end})
+	}
+	return spans
+}
+
+// afterFileScheme reports whether text[:i] ends with a file URL scheme,
+// as in "file:///home/jsmith" or "file:///C:/Users/jsmith".
+func afterFileScheme(text string, i int) bool {
+	before := strings.ToLower(text[max(i-len("file:///"), 0):i])
+	return strings.HasSuffix(before, "file://") || strings.HasSuffix(before, "file:///")
+}
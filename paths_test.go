@@ -0,0 +1,36 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_HomePaths tests redacting usernames in home directory paths
+func TestRedactionEngine_HomePaths(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"open /home/jsmith/.ssh/id_rsa failed", "open /home/[USERNAME]/.ssh/id_rsa failed"},
+		{"cwd=/Users/jane.doe", "cwd=/Users/[USERNAME]"},
+		{`loading C:\Users\jsmith\AppData\Local\app.log`, `loading C:\Users\[USERNAME]\AppData\Local\app.log`},
+		{`path "c:/users/bob/Desktop"`, `path "c:/users/[USERNAME]/Desktop"`},
+		{"open file:///home/jsmith/notes.txt", "open file:///home/[USERNAME]/notes.txt"},
+		{"open FILE:///C:/Users/jsmith/notes.txt", "open FILE:///C:/Users/[USERNAME]/notes.txt"},
+		// Closing punctuation is not part of the username
+		{"(/home/jsmith)", "(/home/[USERNAME])"},
+		{"[/home/jsmith], /home/jane; cd /Users/bob.", "[/home/[USERNAME]], /home/[USERNAME]; cd /Users/[USERNAME]."},
+		// Shared profiles and URL paths are not usernames
+		{`C:\Users\Public\Documents`, `C:\Users\Public\Documents`},
+		{"see https://example.com/home/about", "see https://example.com/home/about"},
+		{"/var/log/home.log", "/var/log/home.log"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
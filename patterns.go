@@ -4,6 +4,9 @@ import (
 	"regexp"
 )
 
+// digitChars is the Requires hint of patterns that only match digits.
+const digitChars = "0123456789"
+
 // builtinPatterns defines the standard PII detection patterns.
 //
 // Each pattern includes a name, regex pattern, and optional validation function.
@@ -11,74 +14,211 @@ import (
 // in the configuration.
 var builtinPatterns = []PatternDef{
 	// Social Security Number (SSN)
-	// Matches formats like 123-45-6789 or 123456789
+	// Matches formats like 123-45-6789, 123.45.6789, 123 45 6789 or 123456789,
+	// with the same separator between every group
+	{
+		Name:        "SSN",
+		Description: "Social Security Number",
+		Regex:       regexp.MustCompile(`\b(?:\d{3}-\d{2}-\d{4}|\d{3}\.\d{2}\.\d{4}|\d{3} \d{2} \d{4}|\d{9})\b`),
+		Validate:    validateSSN,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
+	},
+
+	// Individual Taxpayer Identification Number (ITIN)
+	// Matches SSN-shaped numbers in the 9xx area reserved for ITINs, with the
+	// same separators as SSN
 	{
-		Name:     "SSN",
-		Regex:    regexp.MustCompile(`\b(?:\d{3}-\d{2}-\d{4}|\d{9})\b`),
-		Validate: validateSSN,
+		Name:        "ITIN",
+		Description: "Individual Taxpayer Identification Number",
+		Regex:       regexp.MustCompile(`\b(?:9\d{2}-\d{2}-\d{4}|9\d{2}\.\d{2}\.\d{4}|9\d{2} \d{2} \d{4}|9\d{8})\b`),
+		Validate:    validateITIN,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
 	},
 
 	// Credit Card Number (CC)
 	// Matches major card formats with appropriate prefixes
 	{
-		Name:     "CC",
-		Regex:    regexp.MustCompile(`\b(?:\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}|\d{16})\b`),
-		Validate: validateLuhn,
+		Name:        "CC",
+		Description: "Credit card number",
+		Regex:       regexp.MustCompile(`\b(?:\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}|\d{16})\b`),
+		Validate:    validateLuhn,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
 	},
 
 	// Phone Number (PHONE)
-	// Matches various US formats
+	// Matches various US formats, including "(404) 555-1212" and an
+	// optional extension such as "ext. 42" or "x42"
 	{
-		Name:     "PHONE",
-		Regex:    regexp.MustCompile(`\b(?:\+?1[- ]?)?(?:\([0-9]{3}\)[- ]?|[0-9]{3}[- ]?)[0-9]{3}[- ]?[0-9]{4}\b`),
-		Validate: nil,
+		Name:        "PHONE",
+		Description: "Phone number",
+		Regex:       regexp.MustCompile(`(?:\+?\b1[- ]?)?(?:\([0-9]{3}\)[- ]?|\b[0-9]{3}[- ]?)[0-9]{3}[- ]?[0-9]{4}\b(?:\s*(?:ext\.?|extension|x)\s*[0-9]{1,5}\b)?`),
+		Validate:    nil,
+		Sensitivity: SensitivityMedium,
+		Requires:    digitChars,
 	},
 
 	// Bank Routing Number (ABA)
 	// Matches 9-digit ABA routing numbers
 	{
-		Name:     "ABA",
-		Regex:    regexp.MustCompile(`\b[0-9]{9}\b`),
-		Validate: validateABA,
+		Name:        "ABA",
+		Description: "Bank routing number",
+		Regex:       regexp.MustCompile(`\b[0-9]{9}\b`),
+		Validate:    validateABA,
+		Sensitivity: SensitivityLow,
+		Requires:    digitChars,
+	},
+
+	// Bank routing and account number pair (BANK_INFO)
+	// Matches a valid ABA routing number and the account number after it
+	{
+		Name:        "BANK_INFO",
+		Description: "Bank routing and account number",
+		Regex:       accountRegex,
+		Priority:    bankPriority,
+		find:        findBankInfo,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
 	},
 
 	// Driver's License (DL)
 	// Matches common formats across multiple states
 	{
-		Name:     "DL",
-		Regex:    regexp.MustCompile(`\b(?:[A-Z][0-9]{7}|[A-Z][0-9]{8}|[A-Z]{2}[0-9]{6}|[0-9]{9})\b`),
-		Validate: nil,
+		Name:        "DL",
+		Description: "Driver's license number",
+		Regex:       regexp.MustCompile(`\b(?:[A-Z][0-9]{7}|[A-Z][0-9]{8}|[A-Z]{2}[0-9]{6}|[0-9]{9})\b`),
+		Validate:    nil,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
 	},
 
 	// Email Address (EMAIL)
 	// Matches standard email address format
 	{
-		Name:     "EMAIL",
-		Regex:    regexp.MustCompile(`\b[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}\b`),
-		Validate: nil,
+		Name:        "EMAIL",
+		Description: "Email address",
+		Regex:       regexp.MustCompile(`\b[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}\b`),
+		Validate:    nil,
+		Sensitivity: SensitivityMedium,
+		Requires:    "@",
 	},
 
 	// IP Address (IP)
 	// Matches IPv4 addresses
 	{
-		Name:     "IP",
-		Regex:    regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\b`),
-		Validate: nil,
+		Name:        "IP",
+		Description: "IP address",
+		Regex:       regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\b`),
+		Validate:    nil,
+		Sensitivity: SensitivityLow,
+		Requires:    digitChars,
 	},
 
 	// Passport Number (PASSPORT)
 	// Matches common US passport format
 	{
-		Name:     "PASSPORT",
-		Regex:    regexp.MustCompile(`\b[A-Z][0-9]{8}\b`),
-		Validate: nil,
+		Name:        "PASSPORT",
+		Description: "Passport number",
+		Regex:       regexp.MustCompile(`\b[A-Z][0-9]{8}\b`),
+		Validate:    nil,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
 	},
 
 	// Date of Birth (DOB)
 	// Matches common date formats
 	{
-		Name:     "DOB",
-		Regex:    regexp.MustCompile(`\b(?:0[1-9]|1[0-2])[/.-](?:0[1-9]|[12][0-9]|3[01])[/.-](?:19|20)\d{2}\b`),
-		Validate: nil,
+		Name:        "DOB",
+		Description: "Date of birth",
+		Regex:       regexp.MustCompile(`\b(?:0[1-9]|1[0-2])[/.-](?:0[1-9]|[12][0-9]|3[01])[/.-](?:19|20)\d{2}\b`),
+		Validate:    nil,
+		Sensitivity: SensitivityMedium,
+		Requires:    digitChars,
+	},
+
+	// Username in a home directory path (USERNAME)
+	// Matches the user segment of paths like /home/jsmith or C:\Users\jsmith
+	{
+		Name:        "USERNAME",
+		Description: "Username in a home directory path",
+		Regex:       homePathRegex,
+		find:        findHomeUsername,
+		Sensitivity: SensitivityLow,
+		Requires:    `/\`,
+	},
+}
+
+// optionalPatterns are builtin patterns that are disabled by default and
+// only active when named in Config.EnabledPatterns.
+var optionalPatterns = []PatternDef{
+	// International Mobile Equipment Identity (IMEI)
+	// Matches 15 digits, optionally grouped as 2-6-6-1 like "35-209900-176148-1"
+	{
+		Name:        "IMEI",
+		Description: "Mobile device identifier (IMEI)",
+		Regex:       regexp.MustCompile(`\b\d{2}[- ]?\d{6}[- ]?\d{6}[- ]?\d\b`),
+		Validate:    validateIMEI,
+		cues:        []string{"imei", "device id", "serial", "handset"},
+		Sensitivity: SensitivityMedium,
+		Requires:    digitChars,
+	},
+
+	// Medicare Beneficiary Identifier (MBI)
+	// Matches 11-character MBIs like "1EG4-TE5-MK73", with or without dashes
+	{
+		Name:        "MBI",
+		Description: "Medicare Beneficiary Identifier",
+		Regex:       regexp.MustCompile(`(?i)\b[0-9][A-Z][A-Z0-9][0-9]-?[A-Z][A-Z0-9][0-9]-?[A-Z]{2}[0-9]{2}\b`),
+		Validate:    validateMBI,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
+	},
+
+	// UK NHS number (NHS)
+	// Matches 10 digits like "943 476 5919", spaced 3-3-4 or contiguous;
+	// outranks PHONE, which matches the same shapes
+	{
+		Name:        "NHS",
+		Description: "UK NHS number",
+		Regex:       regexp.MustCompile(`\b(?:\d{3} \d{3} \d{4}|\d{10})\b`),
+		Validate:    validateNHS,
+		Priority:    1,
+		Sensitivity: SensitivityHigh,
+		Requires:    digitChars,
+	},
+
+	// Age over the configured threshold (AGE)
+	// Matches "92 years old", "age 92" and similar, generalizing the number to "90+"
+	{
+		Name:        "AGE",
+		Description: "Age over 89",
+		Regex:       ageRegex,
+		find:        findAges,
+		Sensitivity: SensitivityMedium,
+		Requires:    digitChars,
+	},
+
+	// Password mentioned inline (PASSWORD)
+	// Matches the token after a cue phrase, as in "my password is hunter2"
+	{
+		Name:            "PASSWORD",
+		Description:     "Password",
+		Regex:           passwordTokenRegex,
+		find:            findPasswords,
+		ContextValidate: afterPasswordCue,
+		Sensitivity:     SensitivityHigh,
+	},
+
+	// Gift card or coupon code (GIFTCARD)
+	// Matches dashed codes like "X7KQ-9MBD-2PLT" after a cue such as "gift card" or "code is"
+	{
+		Name:            "GIFTCARD",
+		Description:     "Gift card or coupon code",
+		Regex:           giftCardRegex,
+		ContextValidate: afterGiftCardCue,
+		Sensitivity:     SensitivityMedium,
+		Requires:        "-",
 	},
 }
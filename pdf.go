@@ -0,0 +1,122 @@
+package piiredact
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// PDF layout normalization.
+//
+// Text extracted from PDFs keeps the layout of the page: words are
+// hyphenated across line breaks ("jane.doe@exam-\nple.com"), soft hyphens
+// mark where they could be, and columns and page breaks leave runs of
+// spaces, tabs, newlines and form feeds. With Config.PDFLayout the engine
+// scans a normalized copy of the text in which
+//
+//   - a hyphen at the end of a line between two letters is removed along
+//     with the line break, joining the word; between two digits the hyphen
+//     is kept and only the line break removed, so "401-\n23-4567" reads
+//     "401-23-4567"
+//   - soft hyphens (U+00AD) are removed
+//   - every other run of whitespace becomes a single space
+//
+// As with NormalizeWhitespace, matches are mapped back to the original
+// text, so a value broken across lines is redacted as a whole, hyphen and
+// line break included, and reported in original-text offsets.
+
+// softHyphen is the invisible hyphenation point some extractors keep.
+const softHyphen = '\u00ad'
+
+// normalizeLayout undoes PDF line-break hyphenation and collapses layout
+// whitespace in text. It returns the normalized text and, for each of its
+// bytes, the offset of that byte in text; origin is nil if nothing changed.
+func normalizeLayout(text string) (normalized string, origin []int) {
+	buf := make([]byte, 0, len(text))
+	changed := false
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == softHyphen:
+			changed = true
+			i += size
+
+		case r == '-':
+			end, next := lineBreakAfter(text, i+1)
+			prev, _ := utf8.DecodeLastRuneInString(text[:i])
+			if end < 0 || !isWordRune(prev) || !isWordRune(next) {
+				buf = append(buf, '-')
+				origin = append(origin, i)
+				i++
+				break
+			}
+			if unicode.IsDigit(prev) && unicode.IsDigit(next) {
+				buf = append(buf, '-')
+				origin = append(origin, i)
+			}
+			changed = true
+			i = end
+
+		case unicode.IsSpace(r):
+			j := i
+			for j < len(text) {
+				r, size := utf8.DecodeRuneInString(text[j:])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				j += size
+			}
+			changed = changed || j-i > 1 || text[i] != ' '
+			buf = append(buf, ' ')
+			origin = append(origin, i)
+			i = j
+
+		default:
+			buf = append(buf, text[i:i+size]...)
+			origin = appendOffsets(origin, i, size)
+			i += size
+		}
+	}
+
+	if !changed {
+		return text, nil
+	}
+	return string(buf), origin
+}
+
+// lineBreakAfter returns the offset just past the line break starting at i,
+// including blanks on either side of it, and the rune that follows. It
+// returns -1 if text does not break the line at i.
+func lineBreakAfter(text string, i int) (end int, next rune) {
+	j := i
+	for j < len(text) && (text[j] == ' ' || text[j] == '\t') {
+		j++
+	}
+	if j < len(text) && text[j] == '\r' {
+		j++
+	}
+	if j >= len(text) || text[j] != '\n' {
+		return -1, 0
+	}
+	j++
+	for j < len(text) && (text[j] == ' ' || text[j] == '\t') {
+		j++
+	}
+	next, _ = utf8.DecodeRuneInString(text[j:])
+	return j, next
+}
+
+// composeOrigins maps the offsets inner gives into a text that was itself
+// normalized with outer, so the result maps straight to the original.
+// Either may be nil, meaning that step changed nothing.
+func composeOrigins(outer, inner []int) []int {
+	if outer == nil || inner == nil {
+		if inner == nil {
+			return outer
+		}
+		return inner
+	}
+	for i, o := range inner {
+		inner[i] = outer[o]
+	}
+	return inner
+}
@@ -0,0 +1,90 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestNormalizeLayout tests undoing PDF hyphenation and layout whitespace
+func TestNormalizeLayout(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"jane.doe@exam-\nple.com", "jane.doe@example.com"},
+		{"num-\r\n  ber", "number"},
+		{"SSN 401-\n23-4567", "SSN 401-23-4567"},
+		{"a\u00adb", "ab"},
+		{"col 1\t\t\tcol 2\f\npage", "col 1 col 2 page"},
+		{"well-known", "well-known"},
+		{"list -\nitem", "list - item"},
+		{"plain text", "plain text"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		normalized, origin := normalizeLayout(tc.text)
+		if normalized != tc.expected {
+			t.Errorf("normalizeLayout(%q) = %q, expected %q", tc.text, normalized, tc.expected)
+		}
+		if (origin == nil) != (normalized == tc.text) {
+			t.Errorf("normalizeLayout(%q): unexpected origin %v", tc.text, origin)
+		}
+		for i, o := range origin {
+			if c := normalized[i]; tc.text[o] != c && !(c == ' ' && tc.text[o] <= ' ') {
+				t.Errorf("normalizeLayout(%q): byte %d maps to %d", tc.text, i, o)
+			}
+		}
+	}
+}
+
+// TestRedactionEngine_PDFLayout tests that values broken across PDF lines
+// are redacted whole and reported in original-text offsets
+func TestRedactionEngine_PDFLayout(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Contact: jane.doe@exam-\nple.com today", "Contact: [EMAIL] today"},
+		{"Contact: jane.do-\ne@example.com", "Contact: [EMAIL]"},
+		{"card 4111-1111-\n1111-1111 ok", "card [CC] ok"},
+		{"SSN 401-\n23-4567 on file", "SSN [SSN] on file"},
+		{"Phone 404\n555\t1212", "Phone [PHONE]"},
+		{"Page 1\f\fno identifiers here", "Page 1\f\fno identifiers here"},
+	}
+
+	config := DefaultConfig()
+	config.PDFLayout = true
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %q\nExpected: %q\nGot: %q", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	text := "mail jane.doe@exam-\nple.com"
+	detections, _ := engine.Detect(text)
+	if len(detections) != 1 || detections[0].Value != "jane.doe@exam-\nple.com" || detections[0].Start != 5 {
+		t.Errorf("Expected one EMAIL detection at 5 in original offsets, got %+v", detections)
+	}
+
+	// Without the option the broken values are left alone
+	result, _ := NewRedactionEngine(DefaultConfig()).Process([]Chunk{{UUID: "u", Speaker: "A", Text: "SSN 401-\n23-4567"}})
+	if expected := "SSN 401-\n23-4567"; result[0].Text != expected {
+		t.Errorf("Expected: %q\nGot: %q", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_PDFLayoutNormalizeWhitespace tests combining both normalizations
+func TestRedactionEngine_PDFLayoutNormalizeWhitespace(t *testing.T) {
+	config := DefaultConfig()
+	config.PDFLayout = true
+	config.NormalizeWhitespace = true
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "SSN 4 0 1 - 2 3 -\n4 5 6 7 end"}})
+	if expected := "SSN [SSN] end"; result[0].Text != expected {
+		t.Errorf("Expected: %q\nGot: %q", expected, result[0].Text)
+	}
+}
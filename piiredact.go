@@ -20,9 +20,13 @@ package piiredact
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 )
 
@@ -31,10 +35,12 @@ import (
 // UUID is a unique identifier (e.g., uuidv7) assigned to this chunk.
 // Speaker is the speaker label (e.g., "A", "B") if available.
 // Text is the spoken text, potentially redacted by RedactChunk.
+// Lang is an optional language tag used with Config.LanguagePatterns.
 type Chunk struct {
-	UUID    string `json:"uuid"`    // Unique identifier for the chunk
-	Speaker string `json:"speaker"` // Speaker identifier (e.g., "A", "B")
-	Text    string `json:"text"`    // Text content, potentially containing PII
+	UUID    string `json:"uuid"`           // Unique identifier for the chunk
+	Speaker string `json:"speaker"`        // Speaker identifier (e.g., "A", "B")
+	Text    string `json:"text"`           // Text content, potentially containing PII
+	Lang    string `json:"lang,omitempty"` // Language tag such as "en" or "es" (optional)
 }
 
 // PatternDef defines a pattern for PII detection and its validation function.
@@ -42,25 +48,211 @@ type Chunk struct {
 // Name is used in replacement text (e.g., "[SSN]").
 // Regex is the compiled regular expression that matches the pattern.
 // Validate is an optional function that confirms matches are valid PII.
+// Priority decides which label is used when matches of different patterns
+// overlap; ties go to the pattern listed first.
+// MinLength skips matches with fewer characters, even if the regex fires.
+// Sensitivity places the pattern in a tier for Config.MinSensitivity.
+// GroupID merges nearby matches of patterns in the same group (see group.go).
+// Confidence is how far matches are trusted, for Config.MinConfidence.
+// Requires lets the engine skip the pattern on texts that cannot match it.
+// Description explains the pattern's label in Legend and reports.
 type PatternDef struct {
-	Name     string            // Name of the PII type (used in redaction)
-	Regex    *regexp.Regexp    // Compiled regex pattern for detection
-	Validate func(string) bool // Optional validation function to reduce false positives
+	Name        string            // Name of the PII type (used in redaction)
+	Regex       *regexp.Regexp    // Compiled regex pattern for detection
+	Validate    func(string) bool // Optional validation function to reduce false positives
+	Priority    int               // Wins overlaps against lower priorities (default 0)
+	MinLength   int               // Matches shorter than this many characters are ignored
+	Sensitivity Sensitivity       // Tier of the PII type (default: treated as high)
+	GroupID     string            // Label for adjacent matches of the group, e.g. "ADDRESS" (default: none)
+	Confidence  float64           // Confidence of matches in (0, 1] (default: treated as 1)
+	Requires    string            // Characters every match contains at least one of, e.g. "@" (default: none)
+	Description string            // What the label stands for, e.g. "Social Security Number" (default: the name)
+
+	// ContextValidate optionally checks a match against its surroundings,
+	// given the full text and the match's byte offsets.
+	ContextValidate func(text string, start, end int) bool
+
+	find     func(text string) [][]int               // Optional matcher used in place of Regex
+	rewrite  func(value string, r *redaction) string // Optional replacement that redacts within the value
+	detector Detector                                // External detector whose detections carry their own names
+	cues     []string                                // Lowercase cue words that may precede a match (see Config.RequireCues)
+	terms    []string                                // Literal terms of a dictionary pattern, for ExportSpec
 }
 
+// findAll returns the byte offsets of every candidate match of the pattern.
+//
+// Patterns built internally (such as dictionaries) may supply their own
+// matcher; all others use the compiled regex.
+func (p PatternDef) findAll(text string) [][]int {
+	if p.find != nil {
+		return p.find(text)
+	}
+	return p.Regex.FindAllStringIndex(text, -1)
+}
+
+// RedactionMode selects how detected values are replaced.
+type RedactionMode int
+
+const (
+	// ModeLabel replaces each value with its formatted label, e.g. "[SSN]".
+	ModeLabel RedactionMode = iota
+
+	// ModeFPE replaces SSNs with format-preserving ciphertext that can be
+	// reversed with DecryptSSN; other patterns are labelled as in ModeLabel.
+	ModeFPE
+
+	// ModeToken replaces each value with a stable keyed token such as
+	// "[SSN_3f9a0c1d2b7e]", so equal values can be correlated without
+	// storing them.
+	ModeToken
+
+	// ModeMask replaces letters and digits with 'X' but keeps separators
+	// and, depending on KeepPrefix and KeepSuffix, a few characters at the
+	// ends, e.g. "XXX-XX-6789".
+	ModeMask
+)
+
 // Config provides configuration options for the redaction engine.
 //
-// EnabledPatterns controls which patterns are active.
+// EnabledPatterns controls which patterns are active. A map that sets any
+// pattern to true enables only those patterns; otherwise all default
+// built-in patterns run except those set to false. Optional ones such as
+// IMEI must be set to true explicitly.
 // CustomPatterns allows adding user-defined patterns.
 // RedactionFormat defines how redacted text appears.
 // MaxConcurrency limits parallel processing.
 // Logging enables operational logging.
+// NameDictionary lists personal names to redact as "NAME".
+// Denylist lists arbitrary literal terms to redact as "DENYLIST".
+// LiteralPriority is the overlap priority given to NAME and DENYLIST matches.
+// AuditWriter receives one JSON AuditRecord per redaction.
+// MinLength overrides PatternDef.MinLength by pattern name.
+// Mode selects how detected values are replaced (default ModeLabel).
+// FPEKey is the AES key used by ModeFPE.
+// URLAware redacts PII inside URL query parameters without breaking the URL.
+// TokenKey is the HMAC key used to derive tokens.
+// TokenSidecar receives the token-to-value mapping in ModeToken.
+// UnicodeBoundaries checks word boundaries on letters of every script.
+// DropEmptyChunks omits chunks with empty or whitespace-only Text from output.
+// RedactKeys also redacts the keys of structured data such as RedactMap input.
+// Detectors are consulted after the builtin and custom patterns.
+// Base64Aware redacts base64 blobs whose decoded contents contain PII.
+// Base64MaxDecode is the longest blob Base64Aware will decode.
+// DateShift replaces dates with dates moved by a per-engine random offset.
+// DateShiftDays bounds the DateShift offset.
+// Numbering appends a per-type index to labels, e.g. "[SSN_2]".
+// TrimMatchWhitespace keeps whitespace at the edges of a match out of the redaction.
+// AggressiveBoundaries relaxes \b in AggressivePatterns for glued ASR text.
+// AggressivePatterns names the patterns to relax; empty means all with a validator.
+// RequireCues restricts patterns with cue words to matches preceded by one.
+// KeepPrefix and KeepSuffix choose how much of a value ModeMask reveals.
+// Strictness applies a preset to several of the knobs above (see Strictness).
+// DisableBuiltins turns off every builtin pattern, whatever EnabledPatterns says.
+// SpokenDigits finds phone numbers and SSNs read out as digit words.
+// LuhnExemptPrefixes lists card number prefixes that skip the Luhn check.
+// PreviewSamples keeps up to that many masked values per pattern for Previews.
+// BidiIsolateLabels wraps replacements in directional isolates in RTL text.
+// AgeThreshold is the highest age the optional AGE pattern leaves alone.
+// MinSensitivity skips patterns in lower sensitivity tiers.
+// SkipCurrency ignores numeric matches that are part of currency amounts.
+// EmailPreservePlusTag keeps the "+tag" of masked email addresses visible.
+// InvalidUTF8 chooses whether chunks with invalid UTF-8 are sanitized or flagged.
+// NormalizeWhitespace detects values typed with a space between every character.
+// GroupWindow is how far apart matches of one pattern group may be to merge.
+// MinConfidence and PatternMinConfidence skip matches with lower confidence.
+// RedactDisplayNames also redacts the name in "John Smith <john@example.com>".
+// MaxChunksPerSecond throttles redaction for rate-limited detectors.
+// CCSkipLuhn, CCFragments and CCWrapped widen CC detection (see cards.go).
+// LabelMarker sets inserted replacements apart from text that looks like them.
+// SpokenFillers lets filler words such as "um" interrupt spoken SSN digits.
+// ShareUnchanged lets Process return its input when no chunk was changed.
+// DateOrder sets whether DOB values are read month, day or year first.
+// SkipIPVersions ignores IP matches that follow a word such as "version".
+// WorkerPool runs Process's work on the caller's goroutines (see WorkerPool).
+// SkipIf leaves chunks whose text it matches untouched, such as system messages.
+// ObfuscatedEmails also redacts addresses written as "jane [at] example [dot] com".
+// LabelTemplate renders labels from a text/template (see LabelData).
+// PDFLayout undoes line-break hyphenation and layout whitespace in PDF text.
+// FailClosed redacts a whole chunk rather than trust patterns alone when a Detector fails.
+// TokenScope chooses whether a value's token depends on who said it.
+// BlindIndexKey is the HMAC key for ProcessWithBlindIndex terms (see blindindex.go).
+// LanguagePatterns limits the patterns run on a chunk by its Lang (see language.go).
+// OnMatch can veto individual candidate matches. It runs on worker goroutines
+// and must be safe for concurrent use.
 type Config struct {
-	EnabledPatterns map[string]bool // Map of pattern names to enabled status
-	CustomPatterns  []PatternDef    // Additional user-defined patterns
-	RedactionFormat string          // Format string for redactions (default: "[%s]")
-	MaxConcurrency  int             // Maximum number of concurrent goroutines
-	Logging         bool            // Whether to log redaction operations
+	EnabledPatterns      map[string]bool // Map of pattern names to enabled status
+	CustomPatterns       []PatternDef    // Additional user-defined patterns
+	RedactionFormat      string          // Format string for redactions (default: "[%s]")
+	MaxConcurrency       int             // Maximum number of concurrent goroutines
+	Logging              bool            // Whether to log redaction operations
+	NameDictionary       []string        // Names to redact, matched case-insensitively on word boundaries
+	Denylist             []string        // Literal terms to redact, matched like NameDictionary
+	LiteralPriority      int             // Overlap priority of dictionary matches (builtins use 0)
+	AuditWriter          io.Writer       // Optional append-only audit trail (JSON Lines, no raw values)
+	MinLength            map[string]int  // Per-pattern minimum match length overrides
+	Mode                 RedactionMode   // How detected values are replaced
+	FPEKey               []byte          // 16, 24 or 32 byte AES key for ModeFPE
+	URLAware             bool            // Redact query parameter values in URLs, keeping URLs valid
+	TokenKey             []byte          // HMAC key for tokens (random per engine if empty)
+	TokenSidecar         io.Writer       // Optional JSON Lines token mapping; contains raw PII
+	UnicodeBoundaries    bool            // Check word boundaries in every script, not just ASCII
+	DropEmptyChunks      bool            // Drop empty and whitespace-only chunks instead of passing them through
+	RedactKeys           bool            // Scan map keys as well as values (default false)
+	Detectors            []Detector      // Additional detectors, such as an NERDetector
+	Base64Aware          bool            // Decode base64 blobs and redact those containing PII (see base64.go)
+	Base64MaxDecode      int             // Maximum blob length in characters to decode (default 64 KiB)
+	DateShift            bool            // Shift DOB values instead of redacting them (see dateshift.go)
+	DateShiftDays        int             // Maximum shift in days either way (default 365)
+	Numbering            NumberingScope  // Number labels per chunk or per session (default off)
+	TrimMatchWhitespace  bool            // Leave leading and trailing whitespace of matches in place
+	AggressiveBoundaries bool            // Match values glued to words, relying on validators (see aggressive.go)
+	AggressivePatterns   []string        // Patterns relaxed by AggressiveBoundaries (default: those with Validate)
+	RequireCues          map[string]bool // Patterns that only match after a cue word, e.g. "imei" for IMEI
+	KeepPrefix           int             // Leading letters or digits left visible in ModeMask
+	KeepSuffix           int             // Trailing letters or digits left visible in ModeMask
+	Strictness           Strictness      // Precision/recall preset applied to unset knobs (default Balanced)
+	DisableBuiltins      bool            // Use only custom patterns, dictionaries and detectors
+	SpokenDigits         bool            // Detect PHONE and SSN values such as "four oh four ..." (see spoken.go)
+	LuhnExemptPrefixes   []string        // CC matches starting with one of these are redacted without a Luhn check
+	PreviewSamples       int             // Masked previews kept per pattern (default 0, no sampling)
+	BidiIsolateLabels    bool            // Wrap replacements in LRI/PDI when the text has Arabic, Hebrew or other RTL letters
+	AgeThreshold         int             // Ages above this become "<AgeThreshold+1>+" (default 89, giving "90+")
+	MinSensitivity       Sensitivity     // Only run patterns at or above this tier (default: all)
+	SkipCurrency         bool            // Skip numbers after a currency symbol or before cents, e.g. "$123456789.00"
+	EmailPreservePlusTag bool            // In ModeMask, leave "+tag" of "user+tag@example.com" unmasked
+	InvalidUTF8          UTF8Policy      // Handling of chunks that are not valid UTF-8 (default: not checked)
+	NormalizeWhitespace  bool            // Detect spaced-out values such as "4 0 1 - 2 3 - 4 5 6 7" (see normalize.go)
+	GroupWindow          int             // Maximum bytes between merged matches of a GroupID (default 16)
+	MinConfidence        float64         // Skip matches below this confidence (default 0, keep all; see confidence.go)
+	RedactDisplayNames   bool            // Redact display names before addresses in angle brackets as NAME (see displayname.go)
+	MaxChunksPerSecond   int             // Cap on chunks redacted per second across the engine (default 0, unlimited)
+	CCSkipLuhn           bool            // Redact 13-19 digit card-shaped runs even if they fail the Luhn check
+	CCFragments          bool            // Redact card fragments after cues, e.g. "ends in 1111", as CC_FRAGMENT
+	CCWrapped            bool            // Redact card numbers split across a line break, e.g. "4111 1111\n1111 1111"
+	LabelMarker          string          // Written before and after every replacement, e.g. "\u2063" (default: none)
+	SpokenFillers        bool            // Detect SSN digit runs with fillers, e.g. "1 2 3 um 4 5 ..." (see spoken.go)
+	ShareUnchanged       bool            // Copy the batch only once a chunk changes; results may alias the input
+	DateOrder            DateOrder       // Field order of numeric dates for DOB (default DateMDY)
+	SkipIPVersions       bool            // Skip IP matches after "version", "ver", "v", "release" or "build"
+	WorkerPool           WorkerPool      // Receives one task per chunk instead of engine workers; overrides MaxConcurrency
+	SkipIf               *regexp.Regexp  // Chunks whose Text matches are returned as is, e.g. `^\[SYSTEM\]` (default: none)
+	ObfuscatedEmails     bool            // Redact "at"/"dot" spellings of addresses as EMAIL (see obfuscated.go)
+	PDFLayout            bool            // Join "num-\nber" and collapse layout whitespace before detection (see pdf.go)
+	FailClosed           bool            // Replace the whole chunk with "[REDACTED]" when a Detector fails (default: patterns only)
+	TokenScope           TokenScope      // Share tokens across speakers or give each Chunk.Speaker its own (default TokenGlobal)
+	BlindIndexKey        []byte          // HMAC key for blind index terms; keep apart from TokenKey (default: none, indexing unavailable)
+
+	// PatternMinConfidence overrides MinConfidence for the named patterns, e.g. {"ACCOUNT": 0.9}
+	PatternMinConfidence map[string]float64
+
+	// LabelTemplate renders each label instead of RedactionFormat, e.g. from ParseLabelTemplate("[{{.Pattern}} {{.Value}}]")
+	LabelTemplate *template.Template
+
+	// LanguagePatterns limits chunks with a given Lang to the named patterns, e.g. {"es": {"EMAIL", "PHONE"}} (see language.go)
+	LanguagePatterns map[string][]string
+
+	// OnMatch is called for each candidate match; returning false keeps it unredacted
+	OnMatch func(patternName, value string, start, end int, full string) bool
 }
 
 // DefaultConfig returns a configuration with sensible defaults.
@@ -90,7 +282,14 @@ type Metrics struct {
 	ProcessedChunks  int64            // Total number of chunks processed
 	RedactedItems    map[string]int64 // Count of redactions by pattern type
 	ProcessingTimeNs int64            // Total processing time in nanoseconds
+	SkippedEmpty     int64            // Chunks with empty or whitespace-only Text
+	InvalidUTF8      int64            // Chunks with invalid UTF-8, counted unless UTF8Ignore
+	SkippedMatched   int64            // Chunks left untouched because their Text matched Config.SkipIf
+	BytesProcessed   int64            // Bytes of Text scanned for PII
+	BytesRedacted    int64            // Bytes of Text replaced by redactions
 	mu               sync.Mutex       // Mutex for thread-safe updates
+
+	previews map[string][]string // Masked samples per pattern, see Previews
 }
 
 // newMetrics initializes a new Metrics instance with zeroed counters.
@@ -112,10 +311,27 @@ func newMetrics() *Metrics {
 //
 // It encapsulates configuration, patterns, and metrics for redaction processing.
 type RedactionEngine struct {
-	config   Config       // Configuration options
-	patterns []PatternDef // Active detection patterns
-	logger   *log.Logger  // Optional logger for operations
-	metrics  *Metrics     // Performance and detection metrics
+	config    Config            // Configuration options
+	patterns  []PatternDef      // Active detection patterns
+	logger    *log.Logger       // Optional logger for operations
+	metrics   *Metrics          // Performance and detection metrics
+	fpe       *ff1              // SSN cipher, set only in ModeFPE with a valid key
+	dateShift int               // Days added to dates, set only with DateShift
+	numbers   *labelNumbers     // Session-wide label numbering, set only with NumberingSession
+	tokenKey  []byte            // HMAC key for tokens
+	sidecar   *tokenSidecar     // Token mapping sink for Process, if configured
+	limiter   *rateLimiter      // Paces chunk redaction, set only with MaxChunksPerSecond
+	langSkip  map[string][]bool // Patterns skipped per Chunk.Lang, set only with LanguagePatterns
+	requires  requirements      // Requires metadata of patterns (see requires.go)
+
+	configMu sync.RWMutex // Read-held by exported methods while they use the fields above; written by Reconfigure
+
+	auditMu  sync.Mutex // Serializes writes to the audit writer
+	auditErr error      // First audit write error since the last Process call
+
+	streams   sync.WaitGroup // Running ProcessStream workers, awaited by Close
+	closeOnce sync.Once      // Makes Close idempotent
+	closeErr  error          // Result of the first Close call
 }
 
 // NewRedactionEngine creates a new engine with the given configuration.
@@ -123,43 +339,304 @@ type RedactionEngine struct {
 // It initializes the engine with the specified configuration, compiling
 // all enabled built-in and custom patterns, and setting up metrics tracking.
 func NewRedactionEngine(config Config) *RedactionEngine {
+	engine := &RedactionEngine{metrics: newMetrics()}
+	engine.configure(config)
+	return engine
+}
+
+// configure sets every field of the engine that derives from config,
+// compiling its patterns. Patterns that call back into the engine, such as
+// the URL pass, do so through e, so they always see its current state.
+func (e *RedactionEngine) configure(config Config) {
+	config = applyStrictness(config)
+
 	// Initialize patterns from enabled built-in patterns and custom patterns
 	var patterns []PatternDef
 
 	// Add enabled built-in patterns
 	for _, p := range builtinPatterns {
-		if enabled, exists := config.EnabledPatterns[p.Name]; !exists || enabled {
-			// Include pattern if it's not in the map (default) or explicitly enabled
+		if builtinEnabled(config, p.Name, false) {
+			if p.Name == "EMAIL" && config.UnicodeBoundaries {
+				p.Regex = unicodeEmailRegex
+			}
+			if p.Name == "CC" && len(config.LuhnExemptPrefixes) > 0 {
+				p.Validate = luhnExempt(config.LuhnExemptPrefixes)
+			}
+			if p.Name == "CC" && config.CCSkipLuhn {
+				p.Regex = cardDigitsRegex
+				p.Validate = nil
+			}
+			if p.Name == "CC" && config.CCWrapped {
+				p = wrappedCardPattern(p)
+			}
+			if p.Name == "DOB" {
+				p = datePattern(p, config.DateOrder)
+			}
+			if p.Name == "IP" && config.SkipIPVersions {
+				p = withContext(p, notVersion)
+			}
+			if v, ok := strictValidators[p.Name]; ok && config.Strictness == StrictnessStrict && p.Validate == nil {
+				p.Validate = v
+			}
+			patterns = append(patterns, p)
+		}
+	}
+
+	// Add optional built-in patterns only when explicitly enabled
+	for _, p := range optionalPatterns {
+		if builtinEnabled(config, p.Name, true) {
+			if p.Name == "AGE" {
+				p = agePattern(p, config.AgeThreshold)
+			}
 			patterns = append(patterns, p)
 		}
 	}
 
+	// Card fragments go with CC
+	if config.CCFragments && builtinEnabled(config, "CC", false) {
+		patterns = append(patterns, cardFragmentPattern)
+	}
+
+	// Gate patterns on their cue words where requested
+	for i, p := range patterns {
+		if config.RequireCues[p.Name] && len(p.cues) > 0 {
+			patterns[i] = requireCue(p)
+		}
+	}
+
+	// Add the literal dictionaries, which are active whenever terms are supplied
+	if p, ok := newDictionaryPattern("NAME", config.NameDictionary); ok {
+		p.Priority = config.LiteralPriority
+		patterns = append(patterns, p)
+	}
+	if p, ok := newDictionaryPattern("DENYLIST", config.Denylist); ok {
+		p.Priority = config.LiteralPriority
+		patterns = append(patterns, p)
+	}
+	if config.RedactDisplayNames {
+		patterns = append(patterns, displayNamePattern)
+	}
+	if config.ObfuscatedEmails && builtinEnabled(config, "EMAIL", false) {
+		patterns = append(patterns, obfuscatedEmailPattern)
+	}
+
 	// Add custom patterns
 	patterns = append(patterns, config.CustomPatterns...)
 
+	// Drop patterns below the requested sensitivity tier
+	if config.MinSensitivity != SensitivityUnspecified {
+		kept := patterns[:0]
+		for _, p := range patterns {
+			if p.Sensitivity.effective() >= config.MinSensitivity {
+				kept = append(kept, p)
+			}
+		}
+		patterns = kept
+	}
+
+	// Add external detectors, each behind a pattern slot for overlap priority
+	for _, d := range config.Detectors {
+		if d != nil {
+			patterns = append(patterns, PatternDef{Name: "DETECTOR", detector: d})
+		}
+	}
+
+	// Find spoken phone numbers and SSNs if those patterns are enabled;
+	// SpokenFillers alone looks for SSNs only
+	if config.SpokenDigits || config.SpokenFillers {
+		d := spokenDigitsDetector{fillers: config.SpokenFillers}
+		for _, p := range patterns {
+			d.phone = d.phone || (config.SpokenDigits && p.Name == "PHONE")
+			d.ssn = d.ssn || p.Name == "SSN"
+		}
+		if d.phone || d.ssn {
+			patterns = append(patterns, PatternDef{Name: "DETECTOR", detector: d})
+		}
+	}
+
+	// Relax word boundaries for glued text before any Unicode checks apply
+	if config.AggressiveBoundaries {
+		relax := make(map[string]bool)
+		for _, name := range config.AggressivePatterns {
+			relax[name] = true
+		}
+		for i, p := range patterns {
+			if p.find != nil || p.detector != nil {
+				continue
+			}
+			if relax[p.Name] || (len(relax) == 0 && p.Validate != nil) {
+				patterns[i] = relaxPattern(p)
+			}
+		}
+	}
+
+	// Replace ASCII-only \b semantics for regex patterns, on top of any
+	// context check they already have; dictionaries already check Unicode
+	// boundaries themselves
+	if config.UnicodeBoundaries {
+		for i, p := range patterns {
+			if p.find != nil || p.detector != nil {
+				continue
+			}
+			patterns[i] = withContext(p, unicodeBoundary)
+		}
+	}
+
+	// Leave numbers inside currency amounts alone
+	if config.SkipCurrency {
+		for i, p := range patterns {
+			if currencyCheckedPatterns[p.Name] && p.detector == nil {
+				patterns[i] = withContext(p, notCurrency)
+			}
+		}
+	}
+
+	e.config = config
+
+	// The URL pass calls back into the engine to redact parameter values
+	if config.URLAware {
+		patterns = append(patterns, e.urlPattern())
+	}
+	if config.Base64Aware {
+		patterns = append(patterns, e.base64Pattern())
+	}
+
+	// Apply per-pattern minimum length overrides
+	for i := range patterns {
+		if n, ok := config.MinLength[patterns[i].Name]; ok {
+			patterns[i].MinLength = n
+		}
+	}
+
 	// Create logger if logging is enabled
 	var logger *log.Logger
 	if config.Logging {
 		logger = log.Default()
 	}
 
-	return &RedactionEngine{
-		config:   config,
-		patterns: patterns,
-		logger:   logger,
-		metrics:  newMetrics(),
+	// Prepare the SSN cipher; with an unusable key SSNs are labelled instead
+	var fpe *ff1
+	if config.Mode == ModeFPE {
+		var err error
+		if fpe, err = newFF1(config.FPEKey, nil); err != nil && logger != nil {
+			logger.Printf("FPE disabled: %v", err)
+		}
+	}
+
+	e.patterns = patterns
+	e.requires = newRequirements(patterns)
+	e.logger = logger
+	e.fpe = fpe
+	e.dateShift = 0
+	if config.DateShift {
+		e.dateShift = dateShiftOffset(config.DateShiftDays)
+	}
+	e.numbers = nil
+	if config.Numbering == NumberingSession {
+		e.numbers = newLabelNumbers()
+	}
+	e.tokenKey = tokenKey(config.TokenKey)
+	e.sidecar = newTokenSidecar(config.TokenSidecar)
+	e.limiter = newRateLimiter(config.MaxChunksPerSecond)
+	e.langSkip = languageSkips(patterns, config.LanguagePatterns)
+}
+
+// builtinEnabled reports whether the named builtin pattern is active under
+// config. If EnabledPatterns sets any pattern to true, it is an allowlist
+// and only patterns set to true are included. Otherwise every default
+// builtin is included unless set to false, so {"DL": false} turns off DL
+// alone. Optional patterns always need an explicit true. DisableBuiltins
+// overrides all of this.
+func builtinEnabled(config Config, name string, optional bool) bool {
+	if config.DisableBuiltins {
+		return false
+	}
+	enabled, exists := config.EnabledPatterns[name]
+	if optional || allowlist(config.EnabledPatterns) {
+		return enabled
+	}
+	return !exists || enabled
+}
+
+// allowlist reports whether enabled names any pattern as true, making it
+// an allowlist rather than a list of exceptions to the defaults.
+func allowlist(enabled map[string]bool) bool {
+	for _, on := range enabled {
+		if on {
+			return true
+		}
 	}
+	return false
 }
 
 // Process handles a batch of chunks with metrics and logging.
 //
 // It processes all chunks according to the engine configuration,
-// updates metrics, and returns the redacted chunks.
+// updates metrics, and returns the redacted chunks. Processing is
+// partial-success: every chunk is returned, and if any chunk had a problem,
+// such as invalid UTF-8 or a failing Detector, or if audit records or
+// token mappings could not be written, the error is a *ProcessError that
+// lists what went wrong.
+//
+// In ModeLabel, Process is idempotent: labels such as "[SSN]" or "[SSN_2]"
+// match no pattern, so running it again on its own output returns the
+// same text and counts no new redactions.
+//
+// Chunks without redactions are returned as they were, sharing their Text
+// with the input. With Config.ShareUnchanged the batch is not copied until
+// a chunk changes, so if none does the input slice itself is returned.
+// Callers using it must not modify the returned chunks in place, or the
+// input, while the other is still in use.
 func (e *RedactionEngine) Process(chunks []Chunk) ([]Chunk, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.process(chunks, nil)
+}
+
+// process implements Process, with the caller holding configMu for
+// reading. If inspect is not nil, it is called with the position in chunks
+// and the pass state of every chunk that was redacted, on whichever
+// goroutine redacted it.
+func (e *RedactionEngine) process(chunks []Chunk, inspect func(i int, r *redaction)) ([]Chunk, error) {
 	startTime := time.Now()
 
+	// Collect the problems of each chunk, by its position in chunks
+	var problemsMu sync.Mutex
+	var problems []ChunkError
+	inspectCaller := inspect
+	inspect = func(i int, r *redaction) {
+		if len(r.problems) > 0 {
+			problemsMu.Lock()
+			for _, p := range r.problems {
+				p.Index, p.UUID = i, chunks[i].UUID
+				problems = append(problems, p)
+			}
+			problemsMu.Unlock()
+		}
+		if inspectCaller != nil {
+			inspectCaller(i, r)
+		}
+	}
+
+	// Remove empty chunks before they reach the workers
+	input := chunks
+	if e.config.DropEmptyChunks {
+		input = make([]Chunk, 0, len(chunks))
+		var positions []int
+		for i, c := range chunks {
+			if isEmptyChunk(c) {
+				e.countSkippedEmpty()
+				continue
+			}
+			input = append(input, c)
+			positions = append(positions, i)
+		}
+		inspectInput := inspect
+		inspect = func(i int, r *redaction) { inspectInput(positions[i], r) }
+	}
+
 	// Process chunks with configured concurrency
-	result := e.processChunks(chunks)
+	result := e.processChunks(input, inspect)
 
 	// Update metrics
 	duration := time.Since(startTime)
@@ -173,102 +650,366 @@ func (e *RedactionEngine) Process(chunks []Chunk) ([]Chunk, error) {
 		e.logger.Printf("Processed %d chunks in %v", len(chunks), duration)
 	}
 
-	return result, nil
+	// Surface audit failures so a broken trail is never silently ignored
+	var err error
+	if auditErr := e.takeAuditError(); auditErr != nil {
+		err = fmt.Errorf("piiredact: writing audit record: %w", auditErr)
+	} else if sidecarErr := e.sidecar.writeError(); sidecarErr != nil {
+		err = fmt.Errorf("piiredact: writing token sidecar: %w", sidecarErr)
+	}
+
+	if err == nil && len(problems) == 0 {
+		return result, nil
+	}
+	slices.SortStableFunc(problems, func(a, b ChunkError) int { return a.Index - b.Index })
+	return result, &ProcessError{Chunks: problems, Err: err}
+}
+
+// indexedChunk pairs a chunk with its position in the input batch so
+// workers can write results back in order.
+type indexedChunk struct {
+	index int   // Position of the chunk in the input slice
+	chunk Chunk // Chunk to be redacted
 }
 
 // processChunks handles concurrent processing of multiple chunks.
 //
-// It uses a worker pool with semaphore to limit concurrency based on
-// the engine configuration.
-func (e *RedactionEngine) processChunks(chunks []Chunk) []Chunk {
-	result := make([]Chunk, len(chunks))
+// It starts a fixed set of workers, bounded by the engine configuration,
+// that pull indexed chunks from a shared channel and write each result
+// back to its original position, or submits them to Config.WorkerPool if
+// one is set. If inspect is not nil, it is called with the position and
+// pass state of each chunk that is redacted.
+func (e *RedactionEngine) processChunks(chunks []Chunk, inspect func(i int, r *redaction)) []Chunk {
+	var result []Chunk
+	set := func(i int, c Chunk) { result[i] = c }
+	if e.config.ShareUnchanged {
+		// Copy the batch on the first change, leaving unchanged chunks in place
+		result = chunks
+		var mu sync.Mutex
+		copied := false
+		set = func(i int, c Chunk) {
+			if c == chunks[i] {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !copied {
+				result, copied = slices.Clone(chunks), true
+			}
+			result[i] = c
+		}
+	} else {
+		result = make([]Chunk, len(chunks))
+	}
+	inspectAt := func(i int) func(*redaction) {
+		if inspect == nil {
+			return nil
+		}
+		return func(r *redaction) { inspect(i, r) }
+	}
+
+	// Hand the chunks to the caller's pool, if there is one
+	if pool := e.config.WorkerPool; pool != nil && len(chunks) > 1 {
+		e.processPooled(pool, chunks, func(i int, c Chunk) {
+			set(i, e.redactChunkInspected(c, inspectAt(i)))
+		})
+		return result
+	}
 
 	// If only processing a single chunk or concurrency is set to 1,
 	// process sequentially for better efficiency
-	if len(chunks) == 1 || e.config.MaxConcurrency == 1 {
+	if len(chunks) <= 1 || e.config.MaxConcurrency == 1 {
 		for i, chunk := range chunks {
-			result[i] = e.redactChunk(chunk)
+			set(i, e.redactChunkInspected(chunk, inspectAt(i)))
 		}
 		return result
 	}
 
-	// Otherwise, process concurrently
-	var wg sync.WaitGroup
-
-	// Use a worker pool to limit goroutines
+	// Size the worker pool, never starting more workers than chunks
 	maxWorkers := e.config.MaxConcurrency
 	if maxWorkers <= 0 {
 		maxWorkers = 8 // Fallback to default if invalid
 	}
-	semaphore := make(chan struct{}, maxWorkers)
+	if maxWorkers > len(chunks) {
+		maxWorkers = len(chunks)
+	}
 
-	// Process each chunk in a separate goroutine
-	for i, chunk := range chunks {
-		wg.Add(1)
-		semaphore <- struct{}{} // Acquire semaphore
+	jobs := make(chan indexedChunk)
+	var wg sync.WaitGroup
 
-		go func(i int, c Chunk) {
+	// Start the workers; each one drains the jobs channel until it is closed
+	for w := 0; w < maxWorkers; w++ {
+		wg.Add(1)
+		go func() {
 			defer wg.Done()
-			defer func() { <-semaphore }() // Release semaphore
+			for job := range jobs {
+				set(job.index, e.redactChunkInspected(job.chunk, inspectAt(job.index)))
+			}
+		}()
+	}
 
-			// Process the chunk and store in result
-			result[i] = e.redactChunk(c)
-		}(i, chunk)
+	// Feed the workers; this blocks while all of them are busy
+	for i, chunk := range chunks {
+		jobs <- indexedChunk{index: i, chunk: chunk}
 	}
+	close(jobs)
 
-	wg.Wait() // Wait for all goroutines to complete
+	wg.Wait() // Wait for all workers to finish
 	return result
 }
 
+// redaction carries per-call state through a single redaction pass.
+type redaction struct {
+	counts   map[string]int  // Number of redactions per pattern
+	tokenize bool            // Replace values with stable tokens regardless of Mode
+	sidecar  *tokenSidecar   // Receives new token mappings, if configured
+	numbers  *labelNumbers   // Label numbering for this pass, if configured
+	matches  []match         // Resolved matches of the chunk, set by redactChunkWith
+	text     string          // Text the matches are offsets into, set by redactChunkWith
+	known    map[string]bool // Values left in place, set only by RedactNew
+	problems []ChunkError    // Problems with the chunk, without Index and UUID
+	skip     []bool          // Patterns not run on the chunk, set from Chunk.Lang
+	speaker  string          // Chunk.Speaker, the token namespace with TokenPerSpeaker
+}
+
 // redactChunk applies PII redaction to a single chunk.
 //
 // It processes the text with all active patterns, applying validation
 // where available, and formats redactions according to configuration.
+// Like every unexported method, it expects the caller to hold configMu
+// for reading.
 func (e *RedactionEngine) redactChunk(c Chunk) Chunk {
-	redacted := c.Text
-	redactionCounts := make(map[string]int)
-
-	// Apply each pattern to the text
-	for _, p := range e.patterns {
-		// Find all matches for this pattern
-		matches := p.Regex.FindAllStringIndex(redacted, -1)
-
-		// Process matches in reverse order to avoid offset issues
-		// when replacing text (earlier replacements would change string indices)
-		for i := len(matches) - 1; i >= 0; i-- {
-			match := matches[i]
-			start, end := match[0], match[1]
-			potentialPII := redacted[start:end]
-
-			// Skip validation if no validation function or validation passes
-			if p.Validate == nil || p.Validate(potentialPII) {
-				// Format the redaction according to configuration
-				replacement := fmt.Sprintf(e.config.RedactionFormat, p.Name)
-				redacted = redacted[:start] + replacement + redacted[end:]
-				redactionCounts[p.Name]++
-			}
-		}
+	return e.redactChunkInspected(c, nil)
+}
+
+// redactChunkInspected is redactChunk that also passes the pass state to
+// inspect, if not nil, before it is released. Empty chunks and chunks
+// matching Config.SkipIf are not redacted and not inspected.
+func (e *RedactionEngine) redactChunkInspected(c Chunk, inspect func(r *redaction)) Chunk {
+	// Nothing to detect in empty text
+	if isEmptyChunk(c) {
+		e.countSkippedEmpty()
+		return c
 	}
 
-	// Update metrics with redaction counts
-	if len(redactionCounts) > 0 {
+	// Leave chunks the caller never wants touched, before any pattern runs
+	if e.config.SkipIf != nil && e.config.SkipIf.MatchString(c.Text) {
 		e.metrics.mu.Lock()
-		for name, count := range redactionCounts {
-			e.metrics.RedactedItems[name] += int64(count)
-		}
+		e.metrics.SkippedMatched++
 		e.metrics.mu.Unlock()
+		return c
+	}
+
+	e.limiter.wait()
+	r := e.newRedaction()
+	defer r.release()
+	c = e.redactChunkWith(c, r)
+	if inspect != nil {
+		inspect(r)
+	}
+	return c
+}
+
+// countsPool recycles the per-chunk redaction count maps.
+var countsPool = sync.Pool{
+	New: func() any { return make(map[string]int) },
+}
+
+// newRedaction returns the pass state for redacting one chunk. Call
+// release once its counts are no longer needed.
+func (e *RedactionEngine) newRedaction() *redaction {
+	r := &redaction{
+		counts:  countsPool.Get().(map[string]int),
+		sidecar: e.sidecar,
+		numbers: e.numbers,
+	}
+	if e.config.Numbering == NumberingChunk {
+		r.numbers = newLabelNumbers()
+	}
+	return r
+}
 
-		// Log redactions if enabled
-		if e.config.Logging && e.logger != nil {
-			e.logger.Printf("Chunk %s: redacted %v items", c.UUID, redactionCounts)
+// isEmptyChunk reports whether a chunk's Text is empty or whitespace only.
+func isEmptyChunk(c Chunk) bool {
+	return strings.TrimSpace(c.Text) == ""
+}
+
+// countSkippedEmpty records a chunk that skipped detection for being empty.
+func (e *RedactionEngine) countSkippedEmpty() {
+	e.metrics.mu.Lock()
+	e.metrics.SkippedEmpty++
+	e.metrics.mu.Unlock()
+}
+
+// release returns the pass's counts map to countsPool.
+func (r *redaction) release() {
+	clear(r.counts)
+	countsPool.Put(r.counts)
+	r.counts = nil
+}
+
+// redactChunkWith redacts a chunk using the given pass state, then records
+// audit entries and metrics for it.
+func (e *RedactionEngine) redactChunkWith(c Chunk, r *redaction) Chunk {
+	c, valid := e.checkUTF8(c)
+	if !valid {
+		r.problems = append(r.problems, ChunkError{Kind: ChunkInvalidUTF8})
+	}
+	r.skip = e.langSkip[c.Lang]
+	r.speaker = c.Speaker
+	redacted, matches := e.redactText(c.Text, r)
+	r.matches, r.text = matches, c.Text
+	e.audit(c, matches)
+	e.samplePreviews(c, matches)
+
+	// Count the bytes that were actually replaced
+	var redactedBytes int
+	for _, m := range matches {
+		if m.replacement != c.Text[m.start:m.end] {
+			redactedBytes += m.end - m.start
 		}
 	}
 
+	// Update metrics with byte and redaction counts
+	e.metrics.mu.Lock()
+	e.metrics.BytesProcessed += int64(len(c.Text))
+	e.metrics.BytesRedacted += int64(redactedBytes)
+	for name, count := range r.counts {
+		e.metrics.RedactedItems[name] += int64(count)
+	}
+	e.metrics.mu.Unlock()
+
+	// Log redactions if enabled
+	if len(r.counts) > 0 && e.config.Logging && e.logger != nil {
+		e.logger.Printf("Chunk %s: redacted %v items", c.UUID, r.counts)
+	}
+
 	// Return the redacted chunk
 	c.Text = redacted
 	return c
 }
 
+// redactText detects PII in text and replaces every resolved match,
+// adding the number of redactions per pattern to r.counts. It returns the
+// redacted text and the matches, in original-text offsets, each with its
+// replacement.
+func (e *RedactionEngine) redactText(text string, r *redaction) (string, []match) {
+	matches, err := e.resolvedMatches(text, r.skip)
+	if err != nil {
+		r.problems = append(r.problems, ChunkError{Kind: ChunkDetectorFailed, Message: err.Error()})
+		if e.config.FailClosed {
+			return e.failClosed(text, r)
+		}
+	}
+	if r.known != nil {
+		matches = slices.DeleteFunc(matches, func(m match) bool { return r.known[text[m.start:m.end]] })
+	}
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	// Build replacements in reading order, so numbered labels count up
+	// from the start of the text
+	isolate := e.config.BidiIsolateLabels && hasRTL(text)
+	for i, m := range matches {
+		p := e.patterns[m.pattern]
+		name := e.matchName(m)
+		value := text[m.start:m.end]
+
+		// Rewriting patterns (such as URLs) count their own inner redactions
+		var replacement string
+		if p.rewrite != nil {
+			replacement = p.rewrite(value, r)
+		} else {
+			replacement = e.config.LabelMarker + e.replacement(m, name, value, r) + e.config.LabelMarker
+			r.counts[name]++
+		}
+		matches[i].replacement = bidiSafe(value, replacement, isolate)
+	}
+
+	// A rewrite that changed nothing, because OnMatch, language filtering
+	// or known values kept everything inside it, is not a redaction
+	matches = slices.DeleteFunc(matches, func(m match) bool {
+		return m.replacement == text[m.start:m.end]
+	})
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	// Stitch the text together in one left-to-right pass; matches are
+	// sorted and disjoint after resolveOverlaps
+	var b strings.Builder
+	b.Grow(len(text))
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m.start])
+		b.WriteString(m.replacement)
+		last = m.end
+	}
+	b.WriteString(text[last:])
+
+	return b.String(), matches
+}
+
+// failClosedLabel is the label that replaces a chunk whose detectors failed
+// under Config.FailClosed.
+const failClosedLabel = "REDACTED"
+
+// failClosed replaces all of text with the fail-closed label, as one
+// match attributed to the first detector.
+func (e *RedactionEngine) failClosed(text string, r *redaction) (string, []match) {
+	m := match{start: 0, end: len(text), name: failClosedLabel}
+	for i, p := range e.patterns {
+		if p.detector != nil {
+			m.pattern = i
+			break
+		}
+	}
+	m.replacement = e.config.LabelMarker + fmt.Sprintf(e.config.RedactionFormat, failClosedLabel) + e.config.LabelMarker
+	r.counts[failClosedLabel]++
+	return m.replacement, []match{m}
+}
+
+// replacement returns the text that replaces a detected value.
+//
+// In ModeToken (or when the pass asks for tokens) the value becomes a
+// stable token label. In ModeFPE, SSNs are encrypted in place; if
+// encryption fails the value is labelled rather than left in the clear.
+// With DateShift, dates are shifted in the same way. In ModeMask any
+// other value is masked. Everything else is rendered with LabelTemplate if
+// one is set, or else formatted with RedactionFormat, numbered if Numbering
+// is set.
+func (e *RedactionEngine) replacement(m match, name, value string, r *redaction) string {
+	if r.tokenize || e.config.Mode == ModeToken {
+		label := fmt.Sprintf(e.config.RedactionFormat, e.token(name, e.tokenNamespace(r), value))
+		r.sidecar.record(label, name, value)
+		return label
+	}
+
+	if e.fpe != nil && name == "SSN" {
+		if encrypted, err := e.fpe.encryptSSN(value); err == nil {
+			return encrypted
+		}
+	}
+
+	if e.dateShift != 0 && name == "DOB" {
+		if shifted, ok := shiftDate(value, e.dateShift, e.config.DateOrder); ok {
+			return shifted
+		}
+	}
+
+	if e.config.Mode == ModeMask {
+		return e.mask(value)
+	}
+
+	if e.config.LabelTemplate != nil {
+		return e.templateLabel(m, name, value, r)
+	}
+
+	// Format the redaction according to configuration
+	return fmt.Sprintf(e.config.RedactionFormat, r.numbers.label(name, value))
+}
+
 // GetMetrics returns a copy of the current metrics.
 //
 // This provides a thread-safe way to access the engine's performance
@@ -287,6 +1028,11 @@ func (e *RedactionEngine) GetMetrics() Metrics {
 		ProcessedChunks:  e.metrics.ProcessedChunks,
 		RedactedItems:    redactedItems,
 		ProcessingTimeNs: e.metrics.ProcessingTimeNs,
+		SkippedEmpty:     e.metrics.SkippedEmpty,
+		InvalidUTF8:      e.metrics.InvalidUTF8,
+		SkippedMatched:   e.metrics.SkippedMatched,
+		BytesProcessed:   e.metrics.BytesProcessed,
+		BytesRedacted:    e.metrics.BytesRedacted,
 	}
 }
 
@@ -299,7 +1045,13 @@ func (e *RedactionEngine) ResetMetrics() {
 
 	e.metrics.ProcessedChunks = 0
 	e.metrics.ProcessingTimeNs = 0
+	e.metrics.SkippedEmpty = 0
+	e.metrics.InvalidUTF8 = 0
+	e.metrics.SkippedMatched = 0
+	e.metrics.BytesProcessed = 0
+	e.metrics.BytesRedacted = 0
 	for k := range e.metrics.RedactedItems {
 		e.metrics.RedactedItems[k] = 0
 	}
+	e.metrics.previews = nil
 }
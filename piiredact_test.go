@@ -3,6 +3,7 @@ package piiredact
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -11,20 +12,20 @@ import (
 func TestRedactionEngine_Process(t *testing.T) {
 	// Create test chunks with various PII types
 	chunks := []Chunk{
-		{"id1", "A", "My SSN is 123-45-6789"},
-		{"id2", "B", "My credit card is 4111 1111 1111 1111"},
-		{"id3", "A", "Call me at 555-123-4567"},
-		{"id4", "B", "My email is user@example.com"},
-		{"id5", "A", "No PII in this chunk"},
+		{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789"},
+		{UUID: "id2", Speaker: "B", Text: "My credit card is 4111 1111 1111 1111"},
+		{UUID: "id3", Speaker: "A", Text: "Call me at 555-123-4567"},
+		{UUID: "id4", Speaker: "B", Text: "My email is user@example.com"},
+		{UUID: "id5", Speaker: "A", Text: "No PII in this chunk"},
 	}
 
 	// Expected results after redaction
 	expected := []Chunk{
-		{"id1", "A", "My SSN is [SSN]"},
-		{"id2", "B", "My credit card is [CC]"},
-		{"id3", "A", "Call me at [PHONE]"},
-		{"id4", "B", "My email is [EMAIL]"},
-		{"id5", "A", "No PII in this chunk"},
+		{UUID: "id1", Speaker: "A", Text: "My SSN is [SSN]"},
+		{UUID: "id2", Speaker: "B", Text: "My credit card is [CC]"},
+		{UUID: "id3", Speaker: "A", Text: "Call me at [PHONE]"},
+		{UUID: "id4", Speaker: "B", Text: "My email is [EMAIL]"},
+		{UUID: "id5", Speaker: "A", Text: "No PII in this chunk"},
 	}
 
 	// Create engine with default config
@@ -69,14 +70,14 @@ func TestRedactionEngine_CustomConfig(t *testing.T) {
 
 	// Create test chunks
 	chunks := []Chunk{
-		{"id1", "A", "My SSN is 123-45-6789 and my card is 4111 1111 1111 1111"},
-		{"id2", "B", "Call me at 555-123-4567"},
+		{UUID: "id1", Speaker: "A", Text: "My SSN is 123-45-6789 and my card is 4111 1111 1111 1111"},
+		{UUID: "id2", Speaker: "B", Text: "Call me at 555-123-4567"},
 	}
 
 	// Expected results (only SSN redacted)
 	expected := []Chunk{
-		{"id1", "A", "My SSN is ***SSN*** and my card is 4111 1111 1111 1111"},
-		{"id2", "B", "Call me at 555-123-4567"},
+		{UUID: "id1", Speaker: "A", Text: "My SSN is ***SSN*** and my card is 4111 1111 1111 1111"},
+		{UUID: "id2", Speaker: "B", Text: "Call me at 555-123-4567"},
 	}
 
 	// Create engine with custom config
@@ -108,12 +109,12 @@ func TestRedactionEngine_CustomPattern(t *testing.T) {
 
 	// Create test chunks
 	chunks := []Chunk{
-		{"id1", "A", "My employee ID is EMP-123456"},
+		{UUID: "id1", Speaker: "A", Text: "My employee ID is EMP-123456"},
 	}
 
 	// Expected results
 	expected := []Chunk{
-		{"id1", "A", "My employee ID is [EMPLOYEE_ID]"},
+		{UUID: "id1", Speaker: "A", Text: "My employee ID is [EMPLOYEE_ID]"},
 	}
 
 	// Create engine with custom pattern
@@ -129,6 +130,50 @@ func TestRedactionEngine_CustomPattern(t *testing.T) {
 	}
 }
 
+// TestRedactionEngine_Requires tests skipping patterns on texts without their required characters
+func TestRedactionEngine_Requires(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		// The regex alone matches "TICKET"; Requires only runs it where a '#' appears
+		{Name: "TICKET", Regex: regexp.MustCompile(`\bTICKET(?: #\d+)?`), Requires: "#"},
+	}
+	engine := NewRedactionEngine(config)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"See TICKET #42", "See [TICKET]"},
+		{"See TICKET later", "See TICKET later"},
+		// Builtins still fire where their required characters are present
+		{"Mail jane@example.com, SSN 401-23-4567", "Mail [EMAIL], SSN [SSN]"},
+		{"No digits or at-signs here", "No digits or at-signs here"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_DisableBuiltins tests an engine with only a custom pattern
+func TestRedactionEngine_DisableBuiltins(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableBuiltins = true
+	config.EnabledPatterns["IMEI"] = true
+	config.CustomPatterns = []PatternDef{
+		{Name: "EMPLOYEE_ID", Regex: regexp.MustCompile(`\bEMP-\d{6}\b`)},
+	}
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "EMP-123456 has SSN 123-45-6789, IMEI 490154203237518"}})
+	if expected := "[EMPLOYEE_ID] has SSN 123-45-6789, IMEI 490154203237518"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
 // TestRedactionEngine_Metrics tests metrics collection
 func TestRedactionEngine_Metrics(t *testing.T) {
 	// Create engine
@@ -136,8 +181,8 @@ func TestRedactionEngine_Metrics(t *testing.T) {
 
 	// Process chunks with various PII
 	chunks := []Chunk{
-		{"id1", "A", "SSN: 123-45-6789, Phone: 555-123-4567"},
-		{"id2", "B", "Email: user@example.com"},
+		{UUID: "id1", Speaker: "A", Text: "SSN: 123-45-6789, Phone: 555-123-4567"},
+		{UUID: "id2", Speaker: "B", Text: "Email: user@example.com"},
 	}
 
 	// Process chunks
@@ -208,3 +253,379 @@ func TestRedactionEngine_Concurrency(t *testing.T) {
 	// so we just log the results rather than making it a test failure
 	t.Logf("Single-threaded: %v, Multi-threaded: %v", duration1, duration2)
 }
+
+// TestRedactionEngine_MinLength tests that short spurious matches are ignored
+func TestRedactionEngine_MinLength(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{
+			Name:      "TICKET",
+			Regex:     regexp.MustCompile(`\bTK-\d{1,8}\b`),
+			MinLength: 6,
+		},
+	}
+	// Require the punctuated phone form by overriding the builtin's minimum
+	config.MinLength = map[string]int{"PHONE": 12}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Ticket TK-1234 is open", "Ticket [TICKET] is open"},
+		{"Plan TK-1 is fine", "Plan TK-1 is fine"},
+		{"Call 555-123-4567", "Call [PHONE]"},
+		{"Order 5551234567 shipped", "Order 5551234567 shipped"},
+	}
+
+	engine := NewRedactionEngine(config)
+
+	for _, tt := range tests {
+		result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: tt.input}})
+		if result[0].Text != tt.expected {
+			t.Errorf("MinLength failed for %q:\nExpected: %s\nGot: %s",
+				tt.input, tt.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_EmptyChunks tests skipping and dropping empty chunks
+func TestRedactionEngine_EmptyChunks(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: ""},
+		{UUID: "id2", Speaker: "B", Text: "SSN: 123-45-6789"},
+		{UUID: "id3", Speaker: "A", Text: " \t\n"},
+		{UUID: "id4", Speaker: "B", Text: "nothing to see"},
+	}
+
+	// By default empty chunks pass through unchanged
+	engine := NewRedactionEngine(DefaultConfig())
+	result, _ := engine.Process(chunks)
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 chunks, got %d", len(result))
+	}
+	if result[0].Text != "" || result[2].Text != " \t\n" || result[1].Text != "SSN: [SSN]" {
+		t.Errorf("Unexpected output: %+v", result)
+	}
+	if metrics := engine.GetMetrics(); metrics.SkippedEmpty != 2 || metrics.ProcessedChunks != 4 {
+		t.Errorf("Expected SkippedEmpty=2 and ProcessedChunks=4, got %d and %d",
+			metrics.SkippedEmpty, metrics.ProcessedChunks)
+	}
+
+	// With DropEmptyChunks they are removed from the output
+	config := DefaultConfig()
+	config.DropEmptyChunks = true
+	engine = NewRedactionEngine(config)
+	result, _ = engine.Process(chunks)
+	if len(result) != 2 || result[0].UUID != "id2" || result[1].UUID != "id4" {
+		t.Fatalf("Expected id2 and id4 only, got %+v", result)
+	}
+	if result[0].Text != "SSN: [SSN]" || result[1].Text != "nothing to see" {
+		t.Errorf("Unexpected output: %+v", result)
+	}
+	if metrics := engine.GetMetrics(); metrics.SkippedEmpty != 2 {
+		t.Errorf("Expected SkippedEmpty=2, got %d", metrics.SkippedEmpty)
+	}
+
+	engine.ResetMetrics()
+	if metrics := engine.GetMetrics(); metrics.SkippedEmpty != 0 {
+		t.Errorf("After reset, expected SkippedEmpty=0, got %d", metrics.SkippedEmpty)
+	}
+}
+
+// TestRedactionEngine_EnabledPatterns tests allowlist and exception maps
+func TestRedactionEngine_EnabledPatterns(t *testing.T) {
+	input := "SSN 401-23-4567, DL D1234567, mail jane@example.com"
+	testCases := []struct {
+		enabled  map[string]bool
+		expected string
+	}{
+		{nil, "SSN [SSN], DL [DL], mail [EMAIL]"},
+		{map[string]bool{}, "SSN [SSN], DL [DL], mail [EMAIL]"},
+		// With no true entries, false entries only turn off those patterns
+		{map[string]bool{"DL": false}, "SSN [SSN], DL D1234567, mail [EMAIL]"},
+		{map[string]bool{"DL": false, "IMEI": false}, "SSN [SSN], DL D1234567, mail [EMAIL]"},
+		// Any true entry makes the map an allowlist
+		{map[string]bool{"SSN": true}, "SSN [SSN], DL D1234567, mail jane@example.com"},
+		{map[string]bool{"SSN": true, "DL": false}, "SSN [SSN], DL D1234567, mail jane@example.com"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.EnabledPatterns = tc.enabled
+		result, _ := NewRedactionEngine(config).Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("EnabledPatterns %v\nExpected: %s\nGot: %s", tc.enabled, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_TrimMatchWhitespace tests that matches do not swallow spaces
+func TestRedactionEngine_TrimMatchWhitespace(t *testing.T) {
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"SSN": true}
+	config.CustomPatterns = []PatternDef{
+		{Name: "PHONE", Regex: regexp.MustCompile(`\s+\d{3}-\d{3}-\d{4}\s+`)},
+	}
+	input := "call me 404-555-1212 now"
+
+	engine := NewRedactionEngine(config)
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: input}})
+	if expected := "call me[PHONE]now"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+
+	config.TrimMatchWhitespace = true
+	engine = NewRedactionEngine(config)
+	result, _ = engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: input}})
+	if expected := "call me [PHONE] now"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_ManyMatches tests a chunk with hundreds of matches
+func TestRedactionEngine_ManyMatches(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	text := manyPhonesChunk(500)
+
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: text}})
+	expected := regexp.MustCompile(`404-555-\d{4}`).ReplaceAllString(text, "[PHONE]")
+	if result[0].Text != expected {
+		t.Errorf("Unexpected output for 500 phone numbers:\n%s", result[0].Text)
+	}
+	if metrics := engine.GetMetrics(); metrics.RedactedItems["PHONE"] != 500 {
+		t.Errorf("Expected 500 PHONE redactions, got %d", metrics.RedactedItems["PHONE"])
+	}
+}
+
+// TestRedactionEngine_OnMatch tests vetoing matches with a callback
+func TestRedactionEngine_OnMatch(t *testing.T) {
+	config := DefaultConfig()
+	config.OnMatch = func(patternName, value string, start, end int, full string) bool {
+		if full[start:end] != value {
+			t.Errorf("Offsets %d-%d do not locate %q", start, end, value)
+		}
+		// Company addresses are not personal data
+		return !(patternName == "EMAIL" && strings.HasSuffix(value, "@corp.example.com"))
+	}
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "Email support@corp.example.com or jane@example.com"},
+		{UUID: "id2", Speaker: "B", Text: "SSN 123-45-6789 from help@corp.example.com"},
+	}
+	result, _ := engine.Process(chunks)
+
+	expected := []string{
+		"Email support@corp.example.com or [EMAIL]",
+		"SSN [SSN] from help@corp.example.com",
+	}
+	for i := range expected {
+		if result[i].Text != expected[i] {
+			t.Errorf("Expected: %s\nGot: %s", expected[i], result[i].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_Idempotent tests that redacting already-redacted text
+// changes nothing and counts no new redactions
+func TestRedactionEngine_Idempotent(t *testing.T) {
+	for _, numbering := range []NumberingScope{NumberingOff, NumberingChunk} {
+		config := DefaultConfig()
+		config.Numbering = numbering
+		engine := NewRedactionEngine(config)
+
+		first, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: realisticChunk}})
+		before := engine.GetMetrics()
+		second, _ := engine.Process(first)
+		after := engine.GetMetrics()
+
+		if second[0].Text != first[0].Text {
+			t.Errorf("Numbering %d: second pass changed the text\nFirst: %s\nSecond: %s", numbering, first[0].Text, second[0].Text)
+		}
+		for name, n := range after.RedactedItems {
+			if n != before.RedactedItems[name] {
+				t.Errorf("Numbering %d: second pass redacted %d new %s values", numbering, n-before.RedactedItems[name], name)
+			}
+		}
+	}
+}
+
+// TestRedactionEngine_LargeText tests a multi-megabyte chunk with values
+// straddling the internal scanning windows
+func TestRedactionEngine_LargeText(t *testing.T) {
+	if testing.Short() {
+		t.Skip("scans several megabytes")
+	}
+	const ssn = "401-23-4567"
+	filler := strings.Repeat("lorem ipsum dolor sit amet ", 3*largeTextWindow/27+1000)
+
+	// Start SSNs across, just before and just after each window boundary
+	// and the edges of the overlap around it
+	var positions []int
+	for boundary := largeTextWindow; boundary < len(filler)-largeTextWindow/2; boundary += largeTextWindow {
+		for _, offset := range []int{-largeTextOverlap - 5, -20, -5, 10, largeTextOverlap - 5} {
+			positions = append(positions, boundary+offset)
+		}
+	}
+	var b strings.Builder
+	from := 0
+	for _, pos := range positions {
+		n := pos - b.Len() - 1
+		b.WriteString(filler[from : from+n])
+		b.WriteString(" " + ssn + " ")
+		from += n
+	}
+	b.WriteString(filler[from:])
+	text := b.String()
+	for _, pos := range positions {
+		if text[pos:pos+len(ssn)] != ssn {
+			t.Fatalf("SSN not placed at %d", pos)
+		}
+	}
+	if len(text) < 3<<20 {
+		t.Fatalf("Test text is only %d bytes", len(text))
+	}
+
+	config := DefaultConfig()
+	config.OnMatch = func(patternName, value string, start, end int, full string) bool {
+		if full[start:end] != value || len(full) != len(text) {
+			t.Errorf("OnMatch got offsets %d-%d that do not locate %q in the full text", start, end, value)
+		}
+		return true
+	}
+	engine := NewRedactionEngine(config)
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: text}})
+
+	if n := strings.Count(result[0].Text, "[SSN]"); n != len(positions) {
+		t.Errorf("Expected %d [SSN] labels, got %d", len(positions), n)
+	}
+	if strings.Contains(result[0].Text, ssn) {
+		t.Error("An SSN was left unredacted")
+	}
+	if expected := strings.ReplaceAll(text, ssn, "[SSN]"); result[0].Text != expected {
+		t.Error("Output differs from replacing each SSN once")
+	}
+}
+
+// TestRedactionEngine_ShareUnchanged tests that batches are copied only once a chunk changes
+func TestRedactionEngine_ShareUnchanged(t *testing.T) {
+	plain := []Chunk{{UUID: "id1", Speaker: "A", Text: "hello there"}, {UUID: "id2", Speaker: "B", Text: "nothing to see"}, {UUID: "id3", Speaker: "A", Text: "bye"}}
+	mixed := []Chunk{{UUID: "id1", Speaker: "A", Text: "hello there"}, {UUID: "id2", Speaker: "B", Text: "SSN 123-45-6789"}, {UUID: "id3", Speaker: "A", Text: "bye"}}
+
+	for _, workers := range []int{1, 4} {
+		config := DefaultConfig()
+		config.MaxConcurrency = workers
+		config.ShareUnchanged = true
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process(plain)
+		if &result[0] != &plain[0] {
+			t.Errorf("Workers %d: expected the input slice back when nothing changed", workers)
+		}
+
+		result, _ = engine.Process(mixed)
+		if &result[0] == &mixed[0] {
+			t.Errorf("Workers %d: expected a copy when a chunk changed", workers)
+		}
+		if result[1].Text != "SSN [SSN]" || result[0] != mixed[0] || result[2] != mixed[2] {
+			t.Errorf("Workers %d: unexpected result %+v", workers, result)
+		}
+		if mixed[1].Text != "SSN 123-45-6789" {
+			t.Errorf("Workers %d: input was modified: %q", workers, mixed[1].Text)
+		}
+	}
+
+	// Without the option the result is always a new slice
+	result, _ := NewRedactionEngine(DefaultConfig()).Process(plain)
+	if &result[0] == &plain[0] {
+		t.Error("Expected a new slice without ShareUnchanged")
+	}
+}
+
+// TestRedactionEngine_SkipIf tests leaving chunks that match SkipIf untouched
+func TestRedactionEngine_SkipIf(t *testing.T) {
+	config := DefaultConfig()
+	config.SkipIf = regexp.MustCompile(`^\[SYSTEM\]`)
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "[SYSTEM] call 404-555-1212 recorded at 10.0.0.1"},
+		{UUID: "id2", Speaker: "B", Text: "my SSN is 123-45-6789"},
+		{UUID: "id3", Speaker: "A", Text: "not a [SYSTEM] message: 404-555-1212"},
+		{UUID: "id4", Speaker: "B", Text: ""},
+	}
+	result, _ := engine.Process(chunks)
+
+	expected := []string{chunks[0].Text, "my SSN is [SSN]", "not a [SYSTEM] message: [PHONE]", ""}
+	for i, c := range result {
+		if c.Text != expected[i] {
+			t.Errorf("Chunk %d: expected %q, got %q", i, expected[i], c.Text)
+		}
+	}
+
+	metrics := engine.GetMetrics()
+	if metrics.SkippedMatched != 1 || metrics.SkippedEmpty != 1 || metrics.ProcessedChunks != 4 {
+		t.Errorf("Unexpected metrics: skipped %d, empty %d, processed %d", metrics.SkippedMatched, metrics.SkippedEmpty, metrics.ProcessedChunks)
+	}
+	if metrics.RedactedItems["PHONE"] != 1 || metrics.RedactedItems["IP"] != 0 {
+		t.Errorf("Skipped chunk was counted: %v", metrics.RedactedItems)
+	}
+
+	engine.ResetMetrics()
+	if n := engine.GetMetrics().SkippedMatched; n != 0 {
+		t.Errorf("Expected SkippedMatched reset, got %d", n)
+	}
+}
+
+// TestRedactionEngine_SSNSeparators tests SSNs grouped with dots or spaces
+func TestRedactionEngine_SSNSeparators(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"SSN 401-23-4567", "SSN [SSN]"},
+		{"SSN 401.23.4567", "SSN [SSN]"},
+		{"SSN 401 23 4567", "SSN [SSN]"},
+		{"SSN 401234567", "SSN [SSN]"},
+		// Invalid numbers stay, whatever the separator
+		{"ref 666.23.4567", "ref 666.23.4567"},
+		{"ref 401 00 4567", "ref 401 00 4567"},
+		// Mixed separators are not an SSN layout
+		{"ref 401.23-4567", "ref 401.23-4567"},
+	}
+
+	engine := NewRedactionEngine(DefaultConfig())
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_ByteMetrics tests counting bytes processed and redacted
+func TestRedactionEngine_ByteMetrics(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN: 123-45-6789, Phone: 555-123-4567"}, // 37 bytes, 11 + 12 redacted
+		{UUID: "id2", Speaker: "B", Text: "Email: user@example.com"},               // 23 bytes, 16 redacted
+		{UUID: "id3", Speaker: "A", Text: "nothing here"},                          // 12 bytes
+		{UUID: "id4", Speaker: "B", Text: "  "},                                    // Empty, not scanned
+	}
+	engine.Process(chunks)
+
+	metrics := engine.GetMetrics()
+	if metrics.BytesProcessed != 37+23+12 {
+		t.Errorf("Expected BytesProcessed=72, got %d", metrics.BytesProcessed)
+	}
+	if metrics.BytesRedacted != 11+12+16 {
+		t.Errorf("Expected BytesRedacted=39, got %d", metrics.BytesRedacted)
+	}
+
+	engine.ResetMetrics()
+	metrics = engine.GetMetrics()
+	if metrics.BytesProcessed != 0 || metrics.BytesRedacted != 0 {
+		t.Errorf("Expected byte counts reset, got %d and %d", metrics.BytesProcessed, metrics.BytesRedacted)
+	}
+}
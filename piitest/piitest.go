@@ -0,0 +1,125 @@
+// Package piitest generates synthetic PII for tests.
+//
+// Every value passes the corresponding piiredact validator, so tests no
+// longer need hand-crafted SSNs, Luhn-valid card numbers or routing numbers
+// with correct checksums. Generators are seeded, so a failing test can be
+// reproduced exactly. None of the values belong to real people, though some
+// may coincide with issued numbers by chance; use them only as test data.
+package piitest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Brand selects the issuer prefix and length of a generated card number.
+type Brand int
+
+const (
+	Visa       Brand = iota // 16 digits starting with 4
+	Mastercard              // 16 digits starting with 51-55
+	Discover                // 16 digits starting with 6011
+)
+
+// Generator produces synthetic PII from a seeded random source. It is not
+// safe for concurrent use.
+type Generator struct {
+	rng *rand.Rand
+}
+
+// NewGenerator returns a Generator whose output is determined by seed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rng: rand.New(rand.NewSource(seed))}
+}
+
+// GenerateValidSSN returns an SSN such as "401-23-4567" that follows SSA
+// issuance rules: the area is never 000, 666 or 900-999, and neither the
+// group nor the serial is all zeros.
+func (g *Generator) GenerateValidSSN() string {
+	for {
+		area := 1 + g.rng.Intn(899)
+		if area == 666 {
+			continue
+		}
+		ssn := fmt.Sprintf("%03d-%02d-%04d", area, 1+g.rng.Intn(99), 1+g.rng.Intn(9999))
+
+		// Reject all-same-digit values such as 111-11-1111
+		if strings.Count(ssn, ssn[:1]) == 9 {
+			continue
+		}
+		return ssn
+	}
+}
+
+// GenerateValidCard returns an unformatted card number of the given brand
+// with a valid Luhn check digit.
+func (g *Generator) GenerateValidCard(brand Brand) string {
+	var prefix string
+	switch brand {
+	case Mastercard:
+		prefix = fmt.Sprintf("5%d", 1+g.rng.Intn(5))
+	case Discover:
+		prefix = "6011"
+	default:
+		prefix = "4"
+	}
+
+	digits := []byte(prefix)
+	for len(digits) < 15 {
+		digits = append(digits, byte('0'+g.rng.Intn(10)))
+	}
+	return string(append(digits, luhnCheckDigit(digits)))
+}
+
+// abaPrefixes are the assigned first two digits of routing numbers:
+// 01-12, 21-32, 61-72 and 80.
+var abaPrefixes = func() []int {
+	var prefixes []int
+	for p := 1; p <= 80; p++ {
+		if p <= 12 || (p >= 21 && p <= 32) || (p >= 61 && p <= 72) || p == 80 {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
+}()
+
+// GenerateValidABA returns a nine-digit ABA routing number with an assigned
+// prefix and a valid checksum.
+func (g *Generator) GenerateValidABA() string {
+	d := make([]int, 9)
+	prefix := abaPrefixes[g.rng.Intn(len(abaPrefixes))]
+	d[0], d[1] = prefix/10, prefix%10
+	for i := 2; i < 8; i++ {
+		d[i] = g.rng.Intn(10)
+	}
+
+	// 3(d1+d4+d7) + 7(d2+d5+d8) + (d3+d6+d9) must be divisible by 10
+	sum := 3*(d[0]+d[3]+d[6]) + 7*(d[1]+d[4]+d[7]) + d[2] + d[5]
+	d[8] = (10 - sum%10) % 10
+
+	var b strings.Builder
+	for _, digit := range d {
+		b.WriteByte(byte('0' + digit))
+	}
+	return b.String()
+}
+
+// luhnCheckDigit returns the digit that makes digits followed by it pass
+// the Luhn check.
+func luhnCheckDigit(digits []byte) byte {
+	sum := 0
+	double := true // The check digit itself is not doubled
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,43 @@
+package piitest
+
+import (
+	"testing"
+
+	"github.com/rmasci/piiredact"
+)
+
+// TestGenerator tests that generated values pass the piiredact validators
+func TestGenerator(t *testing.T) {
+	g := NewGenerator(1)
+
+	for i := 0; i < 1000; i++ {
+		checks := []struct {
+			pattern string
+			value   string
+		}{
+			{"SSN", g.GenerateValidSSN()},
+			{"CC", g.GenerateValidCard(Visa)},
+			{"CC", g.GenerateValidCard(Mastercard)},
+			{"CC", g.GenerateValidCard(Discover)},
+			{"ABA", g.GenerateValidABA()},
+		}
+		for _, c := range checks {
+			if valid, err := piiredact.ValidateValue(c.pattern, c.value); !valid || err != nil {
+				t.Fatalf("Generated %s %q is not valid (err %v)", c.pattern, c.value, err)
+			}
+		}
+	}
+}
+
+// TestGenerator_Seeded tests that equal seeds produce equal values
+func TestGenerator_Seeded(t *testing.T) {
+	a, b := NewGenerator(42), NewGenerator(42)
+	for i := 0; i < 10; i++ {
+		if x, y := a.GenerateValidSSN(), b.GenerateValidSSN(); x != y {
+			t.Fatalf("Same seed produced %s and %s", x, y)
+		}
+		if x, y := a.GenerateValidCard(Visa), b.GenerateValidCard(Visa); x != y {
+			t.Fatalf("Same seed produced %s and %s", x, y)
+		}
+	}
+}
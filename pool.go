@@ -0,0 +1,29 @@
+package piiredact
+
+// WorkerPool runs tasks on goroutines managed by the caller, for services
+// that already bound their concurrency with a pool of their own. Set
+// Config.WorkerPool to have Process submit one task per chunk to it rather
+// than start its own workers.
+//
+// Submit must eventually run every task it is given, on any goroutine; it
+// may block until the pool has room. Process waits for all of its tasks,
+// so a pool that drops tasks makes Process hang. ProcessStream keeps
+// its own workers, since its tasks last as long as the stream.
+type WorkerPool interface {
+	Submit(task func())
+}
+
+// processPooled calls redact for each chunk as a task on pool. It returns
+// once every task has finished.
+func (e *RedactionEngine) processPooled(pool WorkerPool, chunks []Chunk, redact func(i int, c Chunk)) {
+	done := make(chan struct{}, len(chunks))
+	for i, chunk := range chunks {
+		pool.Submit(func() {
+			defer func() { done <- struct{}{} }()
+			redact(i, chunk)
+		})
+	}
+	for range chunks {
+		<-done
+	}
+}
@@ -0,0 +1,101 @@
+package piiredact
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingPool is a WorkerPool that runs tasks on a fixed set of
+// goroutines and counts what it was given.
+type countingPool struct {
+	tasks     chan func()
+	submitted atomic.Int64
+}
+
+// newCountingPool starts a countingPool with the given number of goroutines.
+func newCountingPool(workers int) *countingPool {
+	p := &countingPool{tasks: make(chan func())}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// Submit implements WorkerPool.
+func (p *countingPool) Submit(task func()) {
+	p.submitted.Add(1)
+	p.tasks <- task
+}
+
+// inlinePool is a WorkerPool that runs each task inside Submit.
+type inlinePool struct{}
+
+// Submit implements WorkerPool.
+func (inlinePool) Submit(task func()) { task() }
+
+// TestRedactionEngine_WorkerPool tests submitting chunks to a caller-provided pool
+func TestRedactionEngine_WorkerPool(t *testing.T) {
+	chunks := make([]Chunk, 50)
+	for i := range chunks {
+		chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: fmt.Sprintf("chunk %d: SSN 123-45-6789", i)}
+	}
+
+	pool := newCountingPool(3)
+	defer close(pool.tasks)
+
+	for _, p := range []WorkerPool{pool, inlinePool{}} {
+		config := DefaultConfig()
+		config.WorkerPool = p
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process(chunks)
+		for i, c := range result {
+			if expected := fmt.Sprintf("chunk %d: SSN [SSN]", i); c.UUID != chunks[i].UUID || c.Text != expected {
+				t.Errorf("Pool %T: chunk %d: expected %q, got %+v", p, i, expected, c)
+			}
+		}
+		if n := engine.GetMetrics().RedactedItems["SSN"]; n != 50 {
+			t.Errorf("Pool %T: expected 50 SSN redactions, got %d", p, n)
+		}
+	}
+	if n := pool.submitted.Load(); n != 50 {
+		t.Errorf("Expected 50 tasks submitted, got %d", n)
+	}
+
+	// Manifests still see every chunk
+	config := DefaultConfig()
+	config.WorkerPool = pool
+	_, manifest, _ := NewRedactionEngine(config).ProcessWithManifest(chunks)
+	if manifest.ChunksWithPII != 50 {
+		t.Errorf("Expected 50 chunks with PII, got %d", manifest.ChunksWithPII)
+	}
+}
+
+// TestRedactionEngine_WorkerPoolConcurrent tests concurrent Process calls sharing one pool
+func TestRedactionEngine_WorkerPoolConcurrent(t *testing.T) {
+	pool := newCountingPool(2)
+	defer close(pool.tasks)
+
+	config := DefaultConfig()
+	config.WorkerPool = pool
+	engine := NewRedactionEngine(config)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _ := engine.Process([]Chunk{{UUID: "a", Speaker: "A", Text: "call 404-555-1212"}, {UUID: "b", Speaker: "B", Text: "hello"}})
+			if result[0].Text != "call [PHONE]" || result[1].Text != "hello" {
+				t.Errorf("Unexpected result %+v", result)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+package piiredact
+
+// samplePreviews records masked previews of a chunk's redacted values, up
+// to Config.PreviewSamples per pattern. Later values are dropped once a
+// pattern has its quota, so memory stays bounded however much is processed.
+func (e *RedactionEngine) samplePreviews(c Chunk, matches []match) {
+	limit := e.config.PreviewSamples
+	if limit <= 0 || len(matches) == 0 {
+		return
+	}
+
+	e.metrics.mu.Lock()
+	defer e.metrics.mu.Unlock()
+	if e.metrics.previews == nil {
+		e.metrics.previews = make(map[string][]string)
+	}
+	for _, m := range matches {
+		name := e.matchName(m)
+		if len(e.metrics.previews[name]) < limit {
+			e.metrics.previews[name] = append(e.metrics.previews[name], maskValue(c.Text[m.start:m.end]))
+		}
+	}
+}
+
+// Previews returns the masked previews sampled since the engine was created
+// or ResetMetrics was last called, keyed by pattern name, such as
+// {"SSN": ["XXX-XX-6789"]}.
+//
+// Values are masked like AuditRecord.Masked, so previews let a reviewer
+// spot-check what is being caught without keeping raw PII. Sampling is off
+// unless Config.PreviewSamples is set; each pattern keeps its first
+// PreviewSamples values.
+func (e *RedactionEngine) Previews() map[string][]string {
+	e.metrics.mu.Lock()
+	defer e.metrics.mu.Unlock()
+
+	previews := make(map[string][]string, len(e.metrics.previews))
+	for name, values := range e.metrics.previews {
+		previews[name] = append([]string(nil), values...)
+	}
+	return previews
+}
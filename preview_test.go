@@ -0,0 +1,47 @@
+package piiredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRedactionEngine_Previews tests bounded sampling of masked values
+func TestRedactionEngine_Previews(t *testing.T) {
+	config := DefaultConfig()
+	config.PreviewSamples = 2
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 401-23-4567 and 402-23-4567"},
+		{UUID: "id2", Speaker: "B", Text: "SSN 403-23-4567, email jane@example.com"},
+	}
+	if _, err := engine.Process(chunks); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"SSN":   {"XXX-XX-4567", "XXX-XX-4567"},
+		"EMAIL": {"XXXX@XXXXXXe.com"},
+	}
+	previews := engine.Previews()
+	if !reflect.DeepEqual(previews, expected) {
+		t.Errorf("Previews() = %v, expected %v", previews, expected)
+	}
+
+	// The accessor returns a copy, and ResetMetrics clears the samples
+	previews["SSN"][0] = "changed"
+	if engine.Previews()["SSN"][0] != "XXX-XX-4567" {
+		t.Error("Previews() returned the engine's own slice")
+	}
+	engine.ResetMetrics()
+	if previews := engine.Previews(); len(previews) != 0 {
+		t.Errorf("Expected no previews after ResetMetrics, got %v", previews)
+	}
+
+	// Sampling is off by default
+	engine = NewRedactionEngine(DefaultConfig())
+	engine.Process(chunks)
+	if previews := engine.Previews(); len(previews) != 0 {
+		t.Errorf("Expected no previews by default, got %v", previews)
+	}
+}
@@ -0,0 +1,89 @@
+package piiredact
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChunkErrorKind classifies a problem with one chunk of a batch.
+type ChunkErrorKind int
+
+const (
+	// ChunkInvalidUTF8 marks a chunk whose Text was not valid UTF-8. It is
+	// reported only when Config.InvalidUTF8 checks chunks; the chunk is
+	// returned sanitized or as is, following that policy.
+	ChunkInvalidUTF8 ChunkErrorKind = iota + 1
+
+	// ChunkDetectorFailed marks a chunk on which a Detector returned an
+	// error. The chunk is returned redacted by the other patterns and
+	// detectors, or wholly redacted with Config.FailClosed.
+	ChunkDetectorFailed
+
+	// ChunkLabelFailed marks a chunk on which Config.LabelTemplate failed
+	// to execute. The affected values are labelled with RedactionFormat
+	// instead.
+	ChunkLabelFailed
+)
+
+// String returns the kind's name, such as "invalid UTF-8".
+func (k ChunkErrorKind) String() string {
+	switch k {
+	case ChunkInvalidUTF8:
+		return "invalid UTF-8"
+	case ChunkDetectorFailed:
+		return "detector failed"
+	case ChunkLabelFailed:
+		return "label template failed"
+	}
+	return "unknown"
+}
+
+// ChunkError describes a problem with one chunk of a batch.
+type ChunkError struct {
+	Index   int            // Position of the chunk in the input batch
+	UUID    string         // UUID of the chunk
+	Kind    ChunkErrorKind // What went wrong
+	Message string         // Details, such as the detector's error text
+}
+
+// ProcessError is returned by Process and the other batch methods when
+// any chunk had a problem or the batch as a whole could not be completed.
+//
+// Processing is partial-success: every chunk is still returned, in place,
+// whether or not it appears in Chunks, so a caller can keep the results
+// and handle the listed chunks separately. Use errors.As to inspect it;
+// errors.Is sees through it to Err, such as an audit write failure.
+type ProcessError struct {
+	Chunks []ChunkError // Problems with individual chunks, in input order
+	Err    error        // Failure of the batch as a whole, such as writing audit records; nil if none
+}
+
+// Error summarizes the problems, listing each chunk's.
+func (e *ProcessError) Error() string {
+	var b strings.Builder
+	b.WriteString("piiredact: ")
+	if e.Err != nil {
+		b.WriteString(strings.TrimPrefix(e.Err.Error(), "piiredact: "))
+		if len(e.Chunks) > 0 {
+			b.WriteString("; ")
+		}
+	}
+	if len(e.Chunks) > 0 {
+		fmt.Fprintf(&b, "%d chunk problem(s):", len(e.Chunks))
+		for i, c := range e.Chunks {
+			if i > 0 {
+				b.WriteString(";")
+			}
+			fmt.Fprintf(&b, " chunk %d (%s): %s", c.Index, c.UUID, c.Kind)
+			if c.Message != "" {
+				b.WriteString(": " + c.Message)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Unwrap returns Err.
+func (e *ProcessError) Unwrap() error {
+	return e.Err
+}
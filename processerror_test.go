@@ -0,0 +1,149 @@
+package piiredact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingDetector is a Detector that fails on texts containing "fail"
+type failingDetector struct{}
+
+// Detect implements Detector.
+func (failingDetector) Detect(text string) ([]Detection, error) {
+	if strings.Contains(text, "fail") {
+		return nil, errors.New("model unavailable")
+	}
+	return nil, nil
+}
+
+// TestRedactionEngine_ProcessError tests reporting per-chunk problems alongside results
+func TestRedactionEngine_ProcessError(t *testing.T) {
+	config := DefaultConfig()
+	config.InvalidUTF8 = UTF8Flag
+	config.DropEmptyChunks = true
+	config.Detectors = []Detector{failingDetector{}}
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789"},
+		{UUID: "id2", Speaker: "A", Text: ""},
+		{UUID: "id3", Speaker: "B", Text: "bad \xff byte, SSN 123-45-6789"},
+		{UUID: "id4", Speaker: "A", Text: "fail on this one, call 404-555-1212"},
+	}
+	result, err := engine.Process(chunks)
+
+	// Every chunk is still returned, redacted
+	expected := []string{"SSN [SSN]", "bad \xff byte, SSN [SSN]", "fail on this one, call [PHONE]"}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %d", len(expected), len(result))
+	}
+	for i, c := range result {
+		if c.Text != expected[i] {
+			t.Errorf("Chunk %d: expected %q, got %q", i, expected[i], c.Text)
+		}
+	}
+
+	// Problems are listed by position in the input batch
+	var processErr *ProcessError
+	if !errors.As(err, &processErr) {
+		t.Fatalf("Expected a *ProcessError, got %v", err)
+	}
+	want := []ChunkError{
+		{Index: 2, UUID: "id3", Kind: ChunkInvalidUTF8},
+		{Index: 3, UUID: "id4", Kind: ChunkDetectorFailed, Message: "model unavailable"},
+	}
+	if len(processErr.Chunks) != len(want) {
+		t.Fatalf("Expected %d problems, got %+v", len(want), processErr.Chunks)
+	}
+	for i, p := range processErr.Chunks {
+		if p != want[i] {
+			t.Errorf("Problem %d: expected %+v, got %+v", i, want[i], p)
+		}
+	}
+	if processErr.Err != nil {
+		t.Errorf("Expected no batch error, got %v", processErr.Err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "chunk 3 (id4): detector failed: model unavailable") {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+
+	// A clean batch has no error
+	if _, err := engine.Process(chunks[:1]); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestRedactionEngine_ProcessErrorBatch tests batch failures reported through ProcessError
+func TestRedactionEngine_ProcessErrorBatch(t *testing.T) {
+	config := DefaultConfig()
+	config.AuditWriter = failingWriter{}
+	config.InvalidUTF8 = UTF8Sanitize
+	engine := NewRedactionEngine(config)
+
+	_, err := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789 \xff"}})
+	var processErr *ProcessError
+	if !errors.As(err, &processErr) || processErr.Err == nil {
+		t.Fatalf("Expected a *ProcessError with a batch error, got %v", err)
+	}
+	if len(processErr.Chunks) != 1 || processErr.Chunks[0].Kind != ChunkInvalidUTF8 {
+		t.Errorf("Unexpected chunk problems: %+v", processErr.Chunks)
+	}
+	if !strings.Contains(err.Error(), "writing audit record: disk full") {
+		t.Errorf("Unexpected message: %s", err)
+	}
+	if errors.Unwrap(err) != processErr.Err {
+		t.Error("Expected Unwrap to return the batch error")
+	}
+}
+
+// TestRedactionEngine_FailClosed tests both policies for a failing detector
+func TestRedactionEngine_FailClosed(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "fail: SSN 123-45-6789, name Jane Roe"},
+		{UUID: "id2", Speaker: "A", Text: "SSN 123-45-6789"},
+	}
+
+	testCases := []struct {
+		failClosed bool
+		expected   []string
+	}{
+		// Fail open: the failing chunk is redacted by patterns alone
+		{false, []string{"fail: SSN [SSN], name Jane Roe", "SSN [SSN]"}},
+		// Fail closed: nothing of the failing chunk is let through
+		{true, []string{"[REDACTED]", "SSN [SSN]"}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.Detectors = []Detector{failingDetector{}}
+		config.FailClosed = tc.failClosed
+		engine := NewRedactionEngine(config)
+
+		result, err := engine.Process(chunks)
+		for i, c := range result {
+			if c.Text != tc.expected[i] {
+				t.Errorf("FailClosed=%v, chunk %d\nExpected: %s\nGot: %s", tc.failClosed, i, tc.expected[i], c.Text)
+			}
+		}
+
+		var pe *ProcessError
+		if !errors.As(err, &pe) || len(pe.Chunks) != 1 || pe.Chunks[0].UUID != "id1" || pe.Chunks[0].Kind != ChunkDetectorFailed {
+			t.Errorf("FailClosed=%v: expected a ChunkDetectorFailed problem for id1, got %v", tc.failClosed, err)
+		}
+
+		if count := engine.GetMetrics().RedactedItems["REDACTED"]; tc.failClosed != (count == 1) {
+			t.Errorf("FailClosed=%v: unexpected REDACTED count %d", tc.failClosed, count)
+		}
+	}
+
+	// Other entry points fail closed too
+	config := DefaultConfig()
+	config.Detectors = []Detector{failingDetector{}}
+	config.FailClosed = true
+	config.RedactionFormat = "<%s>"
+	text, details := NewRedactionEngine(config).RedactDetailed("fail: jane@example.com")
+	if text != "<REDACTED>" || len(details) != 1 || details[0].PatternName != "REDACTED" {
+		t.Errorf("Expected <REDACTED> with one detail, got %q and %+v", text, details)
+	}
+}
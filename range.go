@@ -0,0 +1,58 @@
+package piiredact
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ProcessRange redacts only the parts of text covered by ranges, returning
+// the full text with everything outside them unchanged.
+//
+// Each range is a pair of byte offsets [start, end) into text, such as the
+// span of a form field. Ranges are clamped to the text, and overlapping or
+// touching ranges are merged; each resulting range is redacted as its own
+// chunk, so a value is only found if it lies entirely inside one range.
+// Like RedactMap, ProcessRange has no error result; audit and token sidecar
+// write failures are reported by the next call to Process.
+func (e *RedactionEngine) ProcessRange(text string, ranges [][2]int) string {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	spans := mergeRanges(ranges, len(text))
+	if len(spans) == 0 {
+		return text
+	}
+
+	result := make([]byte, 0, len(text))
+	last := 0
+	for i, span := range spans {
+		result = append(result, text[last:span[0]]...)
+		chunk := Chunk{UUID: fmt.Sprintf("range-%d", i+1), Text: text[span[0]:span[1]]}
+		result = append(result, e.redactChunk(chunk).Text...)
+		last = span[1]
+	}
+	result = append(result, text[last:]...)
+	return string(result)
+}
+
+// mergeRanges clamps ranges to [0, n], drops empty ones, and returns the
+// rest sorted with overlapping and adjacent ranges merged.
+func mergeRanges(ranges [][2]int, n int) [][2]int {
+	spans := make([][2]int, 0, len(ranges))
+	for _, r := range ranges {
+		start, end := max(r[0], 0), min(r[1], n)
+		if start < end {
+			spans = append(spans, [2]int{start, end})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	merged := spans[:0]
+	for _, s := range spans {
+		if k := len(merged) - 1; k >= 0 && s[0] <= merged[k][1] {
+			merged[k][1] = max(merged[k][1], s[1])
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
@@ -0,0 +1,42 @@
+package piiredact
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestProcessRange tests that only text inside the given ranges is redacted
+func TestProcessRange(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	text := "Name: Jane SSN: 123-45-6789 Notes: call 555-123-4567"
+
+	testCases := []struct {
+		name     string
+		ranges   [][2]int
+		expected string
+	}{
+		{"none", nil, text},
+		{"ssn field", [][2]int{{11, 27}}, "Name: Jane SSN: [SSN] Notes: call 555-123-4567"},
+		{"notes field", [][2]int{{28, 52}}, "Name: Jane SSN: 123-45-6789 Notes: call [PHONE]"},
+		{"both, unsorted", [][2]int{{28, 52}, {11, 27}}, "Name: Jane SSN: [SSN] Notes: call [PHONE]"},
+		{"value cut by range", [][2]int{{0, 20}}, text},
+		{"overlapping ranges merged", [][2]int{{11, 20}, {18, 27}}, "Name: Jane SSN: [SSN] Notes: call 555-123-4567"},
+		{"clamped", [][2]int{{-5, 100}}, "Name: Jane SSN: [SSN] Notes: call [PHONE]"},
+		{"empty and reversed", [][2]int{{5, 5}, {27, 11}}, text},
+	}
+
+	for _, tc := range testCases {
+		if got := engine.ProcessRange(text, tc.ranges); got != tc.expected {
+			t.Errorf("%s: Expected: %s\nGot: %s", tc.name, tc.expected, got)
+		}
+	}
+}
+
+// TestMergeRanges tests clamping, sorting and merging of byte ranges
+func TestMergeRanges(t *testing.T) {
+	got := mergeRanges([][2]int{{8, 10}, {0, 3}, {3, 5}, {9, 20}, {-1, 1}}, 15)
+	expected := [][2]int{{0, 5}, {8, 15}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("mergeRanges() = %v, expected %v", got, expected)
+	}
+}
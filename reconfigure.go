@@ -0,0 +1,71 @@
+package piiredact
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Reconfigure replaces the engine's configuration, recompiling its
+// patterns, without recreating the engine: metrics, previews, pending
+// audit errors and running streams are kept.
+//
+// The swap is atomic. Each call of an exported method works with one
+// configuration throughout: a Process call that is running when
+// Reconfigure is called finishes with the old patterns, and Reconfigure
+// waits for it before swapping; calls that start afterwards use the new
+// ones. Streaming methods such as ProcessStream and RedactNDJSON pick up the
+// new configuration from their next chunk or line.
+//
+// With NumberingSession in both configurations, values keep their label
+// numbers, and an engine with no TokenKey in either keeps its random
+// token key, so tokens and labels stay stable across the reload. With
+// DateShift in both and DateShiftDays unchanged, dates keep moving by the
+// same offset, so intervals between dates redacted before and after the
+// reload are preserved. If TokenSidecar is the same writer, mappings
+// already written are not written again and its pending write error is
+// kept.
+//
+// Reconfigure returns an error, and leaves the engine unchanged, if config
+// cannot be used: ModeFPE with an invalid FPEKey (ErrInvalidFPEKey), or a
+// custom pattern with no name or nothing to match with.
+func (e *RedactionEngine) Reconfigure(config Config) error {
+	if config.Mode == ModeFPE {
+		if _, err := newFF1(config.FPEKey, nil); err != nil {
+			return err
+		}
+	}
+	for i, p := range config.CustomPatterns {
+		if p.Name == "" {
+			return fmt.Errorf("piiredact: custom pattern %d has no name", i)
+		}
+		if p.Regex == nil && p.find == nil && p.detector == nil {
+			return fmt.Errorf("piiredact: custom pattern %s has no regex", p.Name)
+		}
+	}
+
+	e.configMu.Lock()
+	defer e.configMu.Unlock()
+
+	old, numbers, key, sidecar, shift := e.config, e.numbers, e.tokenKey, e.sidecar, e.dateShift
+	e.configure(config)
+	if old.Numbering == NumberingSession && e.numbers != nil {
+		e.numbers = numbers
+	}
+	if len(old.TokenKey) == 0 && len(config.TokenKey) == 0 {
+		e.tokenKey = key
+	}
+	if old.DateShift && config.DateShift && old.DateShiftDays == config.DateShiftDays {
+		e.dateShift = shift
+	}
+	if sameWriter(old.TokenSidecar, config.TokenSidecar) {
+		e.sidecar = sidecar
+	}
+	return nil
+}
+
+// sameWriter reports whether a and b are the same non-nil writer. Writers
+// of types that cannot be compared are never the same.
+func sameWriter(a, b io.Writer) bool {
+	return a != nil && b != nil && reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
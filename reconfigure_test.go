@@ -0,0 +1,165 @@
+package piiredact
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+// TestRedactionEngine_Reconfigure tests swapping configuration on a live engine
+func TestRedactionEngine_Reconfigure(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	engine.Process([]Chunk{{Text: "SSN 123-45-6789"}})
+
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"EMAIL": true}
+	if err := engine.Reconfigure(config); err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+
+	result, _ := engine.Process([]Chunk{{Text: "SSN 123-45-6789, mail jane@example.com"}})
+	if expected := "SSN 123-45-6789, mail [EMAIL]"; result[0].Text != expected {
+		t.Errorf("Expected %q after Reconfigure, got %q", expected, result[0].Text)
+	}
+
+	metrics := engine.GetMetrics()
+	if metrics.ProcessedChunks != 2 || metrics.RedactedItems["SSN"] != 1 || metrics.RedactedItems["EMAIL"] != 1 {
+		t.Errorf("Metrics were not kept across Reconfigure: %d chunks, %v", metrics.ProcessedChunks, metrics.RedactedItems)
+	}
+}
+
+// TestRedactionEngine_ReconfigureInvalid tests that a rejected configuration leaves the engine unchanged
+func TestRedactionEngine_ReconfigureInvalid(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	bad := DefaultConfig()
+	bad.Mode = ModeFPE
+	bad.FPEKey = []byte("short")
+	if err := engine.Reconfigure(bad); !errors.Is(err, ErrInvalidFPEKey) {
+		t.Errorf("Expected ErrInvalidFPEKey, got %v", err)
+	}
+
+	bad = DefaultConfig()
+	bad.CustomPatterns = []PatternDef{{Name: "ORDER"}}
+	if err := engine.Reconfigure(bad); err == nil {
+		t.Error("Expected an error for a custom pattern with no regex")
+	}
+
+	result, _ := engine.Process([]Chunk{{Text: "SSN 123-45-6789"}})
+	if expected := "SSN [SSN]"; result[0].Text != expected {
+		t.Errorf("Expected %q after rejected Reconfigure, got %q", expected, result[0].Text)
+	}
+}
+
+// TestRedactionEngine_ReconfigureStable tests that session numbers, a random token key and the date offset survive Reconfigure
+func TestRedactionEngine_ReconfigureStable(t *testing.T) {
+	config := DefaultConfig()
+	config.Numbering = NumberingSession
+	engine := NewRedactionEngine(config)
+	engine.Process([]Chunk{{Text: "SSN 123-45-6789 and 401-23-4567"}})
+
+	config.EnabledPatterns = map[string]bool{"SSN": true}
+	if err := engine.Reconfigure(config); err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+	result, _ := engine.Process([]Chunk{{Text: "again 401-23-4567"}})
+	if expected := "again [SSN_2]"; result[0].Text != expected {
+		t.Errorf("Expected %q with kept numbering, got %q", expected, result[0].Text)
+	}
+
+	config = DefaultConfig()
+	config.Mode = ModeToken
+	engine = NewRedactionEngine(config)
+	before, _ := engine.Process([]Chunk{{Text: "SSN 123-45-6789"}})
+	if err := engine.Reconfigure(config); err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+	after, _ := engine.Process([]Chunk{{Text: "SSN 123-45-6789"}})
+	if before[0].Text != after[0].Text {
+		t.Errorf("Token changed across Reconfigure: %q vs %q", before[0].Text, after[0].Text)
+	}
+
+	// The date offset is kept unless DateShiftDays changes
+	config = DefaultConfig()
+	config.DateShift = true
+	engine = NewRedactionEngine(config)
+	before, _ = engine.Process([]Chunk{{Text: "born 04/15/1985"}})
+	config.MaxConcurrency = 1
+	if err := engine.Reconfigure(config); err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+	after, _ = engine.Process([]Chunk{{Text: "born 04/15/1985"}})
+	if before[0].Text != after[0].Text {
+		t.Errorf("Date offset changed across Reconfigure: %q vs %q", before[0].Text, after[0].Text)
+	}
+	config.DateShiftDays = 1000
+	if err := engine.Reconfigure(config); err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+	if engine.dateShift < -1000 || engine.dateShift > 1000 || engine.dateShift == 0 {
+		t.Errorf("Expected a new offset within 1000 days, got %d", engine.dateShift)
+	}
+}
+
+// TestRedactionEngine_ReconfigureConcurrent tests that Process calls running
+// alongside Reconfigure see one configuration or the other, never a mix
+func TestRedactionEngine_ReconfigureConcurrent(t *testing.T) {
+	labels := DefaultConfig()
+	masks := DefaultConfig()
+	masks.Mode = ModeMask
+	engine := NewRedactionEngine(labels)
+
+	chunks := make([]Chunk, 20)
+	for i := range chunks {
+		chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Text: "SSN 123-45-6789"}
+	}
+	valid := regexp.MustCompile(`^SSN (\[SSN\]|XXX-XX-6789)$`)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			config := labels
+			if i%2 == 1 {
+				config = masks
+			}
+			if err := engine.Reconfigure(config); err != nil {
+				t.Errorf("Reconfigure failed: %v", err)
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				result, _ := engine.Process(chunks)
+				for i, c := range result {
+					if !valid.MatchString(c.Text) {
+						t.Errorf("Unexpected redaction %q", c.Text)
+						return
+					}
+					if c.Text != result[0].Text {
+						t.Errorf("Chunk %d redacted as %q but chunk 0 as %q in the same call", i, c.Text, result[0].Text)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	<-done
+}
@@ -0,0 +1,116 @@
+package piiredact
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"text/template"
+	"time"
+)
+
+// ReportData is the data RenderReport passes to a report template: the
+// engine's metrics at the time of rendering, with per-pattern statistics.
+type ReportData struct {
+	GeneratedAt     time.Time     // When the report was rendered
+	ProcessedChunks int64         // Metrics.ProcessedChunks
+	SkippedEmpty    int64         // Metrics.SkippedEmpty
+	InvalidUTF8     int64         // Metrics.InvalidUTF8
+	ProcessingTime  time.Duration // Metrics.ProcessingTimeNs as a duration
+	TotalRedactions int64         // Sum of the pattern counts
+	Patterns        []PatternStat // Patterns with at least one redaction, most redacted first
+}
+
+// PatternStat holds one pattern's line of a report.
+type PatternStat struct {
+	Name        string      // Pattern name, as used in labels
+	Count       int64       // Redactions since the metrics were last reset
+	Percent     float64     // Share of TotalRedactions, from 0 to 100
+	Sensitivity Sensitivity // Tier of the pattern; high for labels reported only by detectors
+	Previews    []string    // Masked samples, if Config.PreviewSamples is set
+	Description string      // What the label stands for, as in Legend
+}
+
+// DefaultReportTemplate is the text/template source RenderReport uses when
+// given no template. It renders a plain-text summary such as:
+//
+//	PII redaction report, 2024-05-01T12:00:00Z
+//
+//	Chunks processed: 120 (2 empty, 0 invalid UTF-8) in 15ms
+//	Values redacted:  7
+//
+//	  SSN           4  57.1%  high    Social Security Number
+//	  EMAIL         3  42.9%  medium  Email address
+const DefaultReportTemplate = `PII redaction report, {{.GeneratedAt.Format "2006-01-02T15:04:05Z07:00"}}
+
+Chunks processed: {{.ProcessedChunks}} ({{.SkippedEmpty}} empty, {{.InvalidUTF8}} invalid UTF-8) in {{.ProcessingTime}}
+Values redacted:  {{.TotalRedactions}}
+{{if .Patterns}}
+{{range .Patterns}}  {{printf "%-10s %4d %5.1f%%  %-6s  %s" .Name .Count .Percent .Sensitivity .Description}}{{range .Previews}}
+      {{.}}{{end}}
+{{end}}{{end}}`
+
+// defaultReportTemplate is DefaultReportTemplate, parsed once.
+var defaultReportTemplate = template.Must(template.New("report").Parse(DefaultReportTemplate))
+
+// RenderReport executes tmpl over the engine's Report and writes the
+// result to w, so redaction runs can be summarized for compliance records
+// without hand-formatting. A nil tmpl uses DefaultReportTemplate. For HTML
+// output, execute an html/template over Report instead, so values are
+// escaped; the data holds masked previews only, never raw values.
+//
+// It returns an error if the template fails to execute or w cannot be
+// written.
+func (e *RedactionEngine) RenderReport(w io.Writer, tmpl *template.Template) error {
+	if tmpl == nil {
+		tmpl = defaultReportTemplate
+	}
+	if err := tmpl.Execute(w, e.Report()); err != nil {
+		return fmt.Errorf("piiredact: rendering report: %w", err)
+	}
+	return nil
+}
+
+// Report returns the engine's metrics since it was created or
+// ResetMetrics was last called, as a ReportData.
+func (e *RedactionEngine) Report() ReportData {
+	metrics := e.GetMetrics()
+	previews := e.Previews()
+	data := ReportData{
+		GeneratedAt:     time.Now(),
+		ProcessedChunks: metrics.ProcessedChunks,
+		SkippedEmpty:    metrics.SkippedEmpty,
+		InvalidUTF8:     metrics.InvalidUTF8,
+		ProcessingTime:  time.Duration(metrics.ProcessingTimeNs),
+		Patterns:        []PatternStat{},
+	}
+
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	legend := e.legend(metrics.RedactedItems)
+	sensitivity := make(map[string]Sensitivity)
+	for _, p := range e.patterns {
+		if _, ok := sensitivity[p.Name]; !ok {
+			sensitivity[p.Name] = p.Sensitivity.effective()
+		}
+	}
+	for name, count := range metrics.RedactedItems {
+		if count == 0 {
+			continue
+		}
+		s, ok := sensitivity[name]
+		if !ok {
+			s = SensitivityHigh
+		}
+		data.TotalRedactions += count
+		data.Patterns = append(data.Patterns, PatternStat{Name: name, Count: count, Sensitivity: s, Previews: previews[name], Description: legend[name]})
+	}
+
+	for i := range data.Patterns {
+		data.Patterns[i].Percent = 100 * float64(data.Patterns[i].Count) / float64(data.TotalRedactions)
+	}
+	slices.SortFunc(data.Patterns, func(a, b PatternStat) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return data
+}
@@ -0,0 +1,71 @@
+package piiredact
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// TestRedactionEngine_Report tests the per-pattern statistics of a report
+func TestRedactionEngine_Report(t *testing.T) {
+	config := DefaultConfig()
+	config.PreviewSamples = 1
+	engine := NewRedactionEngine(config)
+	engine.Process([]Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789, email jane@example.com"},
+		{UUID: "id2", Speaker: "B", Text: "SSN 401-23-4567, SSN 234-56-7890"},
+		{UUID: "id3", Speaker: "A", Text: ""},
+	})
+
+	data := engine.Report()
+	if data.ProcessedChunks != 3 || data.SkippedEmpty != 1 || data.TotalRedactions != 4 {
+		t.Errorf("Unexpected totals: %+v", data)
+	}
+	if len(data.Patterns) != 2 {
+		t.Fatalf("Expected 2 patterns, got %+v", data.Patterns)
+	}
+	ssn, email := data.Patterns[0], data.Patterns[1]
+	if ssn.Name != "SSN" || ssn.Count != 3 || ssn.Percent != 75 || ssn.Sensitivity != SensitivityHigh {
+		t.Errorf("Unexpected SSN line: %+v", ssn)
+	}
+	if email.Name != "EMAIL" || email.Count != 1 || email.Sensitivity != SensitivityMedium {
+		t.Errorf("Unexpected EMAIL line: %+v", email)
+	}
+	if len(ssn.Previews) != 1 || ssn.Previews[0] != "XXX-XX-6789" {
+		t.Errorf("Expected one masked SSN preview, got %v", ssn.Previews)
+	}
+}
+
+// TestRedactionEngine_RenderReport tests the default and custom report templates
+func TestRedactionEngine_RenderReport(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+	engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789, email jane@example.com"}})
+
+	var b strings.Builder
+	if err := engine.RenderReport(&b, nil); err != nil {
+		t.Fatalf("RenderReport failed: %v", err)
+	}
+	for _, want := range []string{"Chunks processed: 1 (0 empty", "Values redacted:  2", "SSN           1  50.0%  high", "EMAIL         1  50.0%  medium"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Report lacks %q:\n%s", want, b.String())
+		}
+	}
+	if strings.Contains(b.String(), "123-45-6789") {
+		t.Errorf("Report contains a raw value:\n%s", b.String())
+	}
+
+	tmpl := template.Must(template.New("csv").Parse("{{range .Patterns}}{{.Name}},{{.Count}}\n{{end}}"))
+	b.Reset()
+	if err := engine.RenderReport(&b, tmpl); err != nil {
+		t.Fatalf("RenderReport failed: %v", err)
+	}
+	if expected := "EMAIL,1\nSSN,1\n"; b.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, b.String())
+	}
+
+	// Execution errors are returned
+	bad := template.Must(template.New("bad").Parse("{{.Missing}}"))
+	if err := engine.RenderReport(&b, bad); err == nil {
+		t.Error("Expected an error for a template referencing a missing field")
+	}
+}
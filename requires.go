@@ -0,0 +1,66 @@
+package piiredact
+
+import (
+	"strings"
+)
+
+// Required characters.
+//
+// Most patterns declare PatternDef.Requires, and nearly all of them share
+// one of a few sets: digits for the numeric patterns, "@" for EMAIL and so
+// on. Rather than searching each window once per pattern, the engine
+// collects the distinct sets once at construction and searches each window
+// once per set, recording which are present in a bitset. A pattern is then
+// skipped with a single bit test.
+
+// requirements is the Requires metadata of an engine's patterns.
+type requirements struct {
+	sets []string // Distinct non-empty Requires values
+	of   []int    // Index into sets for each pattern, or -1 if it requires nothing
+}
+
+// newRequirements collects the Requires metadata of patterns.
+func newRequirements(patterns []PatternDef) requirements {
+	q := requirements{of: make([]int, len(patterns))}
+	index := make(map[string]int)
+	for i, p := range patterns {
+		if p.Requires == "" {
+			q.of[i] = -1
+			continue
+		}
+		n, ok := index[p.Requires]
+		if !ok {
+			n = len(q.sets)
+			index[p.Requires] = n
+			q.sets = append(q.sets, p.Requires)
+		}
+		q.of[i] = n
+	}
+	return q
+}
+
+// present returns the bitset of the first 64 sets that text contains at
+// least one character of.
+func (q requirements) present(text string) uint64 {
+	var bits uint64
+	for n, set := range q.sets[:min(len(q.sets), 64)] {
+		if strings.ContainsAny(text, set) {
+			bits |= 1 << n
+		}
+	}
+	return bits
+}
+
+// met reports whether text can match pattern i, given the bitset present
+// returned for it. Sets beyond the first 64 are searched directly.
+func (q requirements) met(i int, text string, present uint64) bool {
+	n := q.of[i]
+	switch {
+	case n < 0:
+		return true
+	case n < 64:
+		return present&(1<<n) != 0
+	default:
+		return strings.ContainsAny(text, q.sets[n])
+	}
+}
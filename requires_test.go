@@ -0,0 +1,55 @@
+package piiredact
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRequirements tests that patterns sharing a Requires value share one set
+func TestRequirements(t *testing.T) {
+	patterns := []PatternDef{
+		{Name: "A", Requires: digitChars},
+		{Name: "B", Requires: "@"},
+		{Name: "C"},
+		{Name: "D", Requires: digitChars},
+	}
+	q := newRequirements(patterns)
+	if len(q.sets) != 2 {
+		t.Fatalf("Expected 2 sets, got %v", q.sets)
+	}
+
+	testCases := []struct {
+		text     string
+		expected []bool
+	}{
+		{"no digits here", []bool{false, false, true, false}},
+		{"call 555", []bool{true, false, true, true}},
+		{"jane@example.com", []bool{false, true, true, false}},
+		{"", []bool{false, false, true, false}},
+	}
+	for _, tc := range testCases {
+		present := q.present(tc.text)
+		for i, expected := range tc.expected {
+			if got := q.met(i, tc.text, present); got != expected {
+				t.Errorf("Text %q: pattern %s met = %v, expected %v", tc.text, patterns[i].Name, got, expected)
+			}
+		}
+	}
+}
+
+// TestRequirements_ManySets tests patterns beyond the bitset's 64 sets
+func TestRequirements_ManySets(t *testing.T) {
+	var patterns []PatternDef
+	for r := 'a'; r < 'a'+70; r++ {
+		patterns = append(patterns, PatternDef{Name: string(r), Requires: string(r)})
+	}
+	q := newRequirements(patterns)
+
+	text := strings.Repeat(string(rune('a'+66)), 2)
+	present := q.present(text)
+	for i := range patterns {
+		if got, expected := q.met(i, text, present), i == 66; got != expected {
+			t.Errorf("Pattern %d met = %v, expected %v", i, got, expected)
+		}
+	}
+}
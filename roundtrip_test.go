@@ -0,0 +1,88 @@
+package piiredact
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/rmasci/piiredact/piitest"
+)
+
+// roundTripCases lists sample valid values for each builtin pattern.
+// Add a row here whenever a new pattern is introduced.
+var roundTripCases = []struct {
+	pattern string
+	values  []string
+}{
+	{"SSN", []string{"401-23-4567", "401234567", "401.23.4567", "401 23 4567"}},
+	{"ITIN", []string{"912-70-1234", "900501234", "912.70.1234", "912 70 1234"}},
+	{"CC", []string{"4111 1111 1111 1111", "4111-1111-1111-1111", "5500000000000004"}},
+	{"PHONE", []string{"404-555-1212", "(404) 555-1212", "+1 404 555 1212", "404-555-1212 ext. 4321", "404-555-1212 x42"}},
+	{"ABA", []string{"111000025"}},
+	{"BANK_INFO", []string{"021000021 account 1234567890", "111000025 acct no. 00012345", "021000021 / 987654321"}},
+	{"DL", []string{"D1234567", "AB123456"}},
+	{"EMAIL", []string{"jane.doe@example.com", "j_smith+calls@mail.example.org"}},
+	{"IP", []string{"192.168.10.25", "8.8.8.8"}},
+	{"PASSPORT", []string{"C12345678"}},
+	{"DOB", []string{"04/15/1985", "12-31-1999"}},
+	{"USERNAME", []string{"/home/jsmith", "/Users/jane.doe/Desktop", `C:\Users\jsmith`, "file:///home/jsmith/notes.txt"}},
+}
+
+// roundTripContexts are the sentences each sample value is embedded in
+var roundTripContexts = []string{
+	"%s",
+	"my value is %s.",
+	"(%s)",
+	"first %s, then more text",
+}
+
+// assertNoLeak redacts value inside context and fails if the output still
+// contains the value or any run of four consecutive digits taken from it.
+func assertNoLeak(t *testing.T, engine *RedactionEngine, pattern, context, value string) {
+	t.Helper()
+
+	input := strings.Replace(context, "%s", value, 1)
+	result, _ := engine.Process([]Chunk{{UUID: "rt", Speaker: "A", Text: input}})
+	redacted := result[0].Text
+
+	if strings.Contains(redacted, value) {
+		t.Errorf("%s leaked verbatim:\nInput: %s\nGot: %s", pattern, input, redacted)
+		return
+	}
+
+	var digits []rune
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+	for i := 0; i+4 <= len(digits); i++ {
+		if run := string(digits[i : i+4]); strings.Contains(redacted, run) {
+			t.Errorf("%s leaked digits %q:\nInput: %s\nGot: %s", pattern, run, input, redacted)
+			return
+		}
+	}
+}
+
+// TestRedactionEngine_RoundTripNoLeak guards against redacted output containing original PII
+func TestRedactionEngine_RoundTripNoLeak(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	for _, tc := range roundTripCases {
+		for _, value := range tc.values {
+			for _, context := range roundTripContexts {
+				assertNoLeak(t, engine, tc.pattern, context, value)
+			}
+		}
+	}
+
+	// Generated values cover far more of each pattern's range
+	g := piitest.NewGenerator(1)
+	for i := 0; i < 200; i++ {
+		for _, context := range roundTripContexts {
+			assertNoLeak(t, engine, "SSN", context, g.GenerateValidSSN())
+			assertNoLeak(t, engine, "CC", context, g.GenerateValidCard(piitest.Visa))
+			assertNoLeak(t, engine, "ABA", context, g.GenerateValidABA())
+		}
+	}
+}
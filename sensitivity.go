@@ -0,0 +1,45 @@
+package piiredact
+
+// Sensitivity ranks how harmful disclosure of a type of PII would be, so
+// Config.MinSensitivity can limit redaction to the most sensitive types.
+type Sensitivity int
+
+const (
+	// SensitivityUnspecified, the zero value, is treated as
+	// SensitivityHigh, so custom patterns and detectors that do not set a
+	// tier are never filtered out.
+	SensitivityUnspecified Sensitivity = iota
+
+	// SensitivityLow is for data that is often public or identifies a
+	// person only indirectly, such as IP addresses and routing numbers.
+	SensitivityLow
+
+	// SensitivityMedium is for contact details and quasi-identifiers, such
+	// as phone numbers, email addresses and dates of birth.
+	SensitivityMedium
+
+	// SensitivityHigh is for identifiers that enable fraud or identity
+	// theft, such as SSNs, card numbers and passport numbers.
+	SensitivityHigh
+)
+
+// String returns the tier's name, such as "high".
+func (s Sensitivity) String() string {
+	switch s {
+	case SensitivityLow:
+		return "low"
+	case SensitivityMedium:
+		return "medium"
+	case SensitivityHigh, SensitivityUnspecified:
+		return "high"
+	}
+	return "unknown"
+}
+
+// effective returns the tier s stands for, resolving SensitivityUnspecified.
+func (s Sensitivity) effective() Sensitivity {
+	if s == SensitivityUnspecified {
+		return SensitivityHigh
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package piiredact
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// TestRedactionEngine_MinSensitivity tests redacting only the higher sensitivity tiers
+func TestRedactionEngine_MinSensitivity(t *testing.T) {
+	input := "SSN 401-23-4567, call 555-123-4567, from 192.168.1.1, ref EMP-123456"
+
+	testCases := []struct {
+		min      Sensitivity
+		expected string
+	}{
+		{SensitivityUnspecified, "SSN [SSN], call [PHONE], from [IP], ref [EMPLOYEE_ID]"},
+		{SensitivityLow, "SSN [SSN], call [PHONE], from [IP], ref [EMPLOYEE_ID]"},
+		{SensitivityMedium, "SSN [SSN], call [PHONE], from 192.168.1.1, ref [EMPLOYEE_ID]"},
+		// Custom patterns without a tier count as high
+		{SensitivityHigh, "SSN [SSN], call 555-123-4567, from 192.168.1.1, ref [EMPLOYEE_ID]"},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.MinSensitivity = tc.min
+		config.CustomPatterns = []PatternDef{
+			{Name: "EMPLOYEE_ID", Regex: regexp.MustCompile(`\bEMP-\d{6}\b`)},
+		}
+		engine := NewRedactionEngine(config)
+
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("MinSensitivity %s: Expected: %s\nGot: %s", tc.min, tc.expected, result[0].Text)
+		}
+	}
+
+	// Every builtin has a tier
+	for _, p := range slices.Concat(builtinPatterns, optionalPatterns) {
+		if p.Sensitivity == SensitivityUnspecified {
+			t.Errorf("Builtin pattern %s has no sensitivity tier", p.Name)
+		}
+	}
+}
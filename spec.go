@@ -0,0 +1,254 @@
+package piiredact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// ErrNotPortable is returned by ExportSpec for custom patterns that cannot
+// be described by a PatternSpec, such as those with a ContextValidate
+// function or an unregistered validator.
+var ErrNotPortable = errors.New("piiredact: pattern is not portable")
+
+// PatternSpec is the portable description of one pattern, as written by
+// ExportSpec and read by LoadPatterns.
+//
+// A spec describes exactly one of: a builtin pattern, by name; a literal
+// dictionary, by its terms; or a regex pattern with an optional validator,
+// by its registered name.
+type PatternSpec struct {
+	Name        string      `json:"name"`                  // Pattern name, used as the redaction label
+	Builtin     bool        `json:"builtin,omitempty"`     // Use the library's builtin pattern of this name
+	Regex       string      `json:"regex,omitempty"`       // Regex source for custom patterns
+	Validator   string      `json:"validator,omitempty"`   // Registered validator name (see RegisterValidator)
+	Terms       []string    `json:"terms,omitempty"`       // Literal terms of a dictionary pattern
+	Priority    int         `json:"priority,omitempty"`    // PatternDef.Priority
+	MinLength   int         `json:"min_length,omitempty"`  // PatternDef.MinLength, including Config.MinLength overrides
+	Sensitivity Sensitivity `json:"sensitivity,omitempty"` // PatternDef.Sensitivity
+	GroupID     string      `json:"group_id,omitempty"`    // PatternDef.GroupID
+	Confidence  float64     `json:"confidence,omitempty"`  // PatternDef.Confidence
+	Requires    string      `json:"requires,omitempty"`    // PatternDef.Requires
+	Description string      `json:"description,omitempty"` // PatternDef.Description
+}
+
+// validators maps registered names to validation functions.
+var validators = struct {
+	sync.RWMutex
+	byName map[string]func(string) bool
+}{byName: map[string]func(string) bool{
+	"ssn":  validateSSN,
+	"itin": validateITIN,
+	"luhn": validateLuhn,
+	"aba":  validateABA,
+	"imei": validateIMEI,
+	"nanp": validateNANP,
+	"mbi":  validateMBI,
+	"nhs":  validateNHS,
+}}
+
+// RegisterValidator makes a validation function available to ExportSpec
+// and LoadPatterns under name. The builtin validators are registered as
+// "ssn", "itin", "luhn", "aba", "imei", "nanp", "mbi" and "nhs".
+// Registering a name again replaces its function.
+func RegisterValidator(name string, validate func(string) bool) {
+	validators.Lock()
+	defer validators.Unlock()
+	validators.byName[name] = validate
+}
+
+// validatorName returns the registered name of validate, comparing
+// function pointers. Closures created by the same function literal share a
+// pointer, so they cannot be told apart and are best registered by a
+// wrapper of their own.
+func validatorName(validate func(string) bool) (string, bool) {
+	ptr := reflect.ValueOf(validate).Pointer()
+	validators.RLock()
+	defer validators.RUnlock()
+	for name, fn := range validators.byName {
+		if reflect.ValueOf(fn).Pointer() == ptr {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ExportSpec describes the engine's patterns as JSON, an array of
+// PatternSpec in the order the engine applies them, so the same redactor
+// can be rebuilt elsewhere with LoadPatterns.
+//
+// Builtin patterns are exported by name, and so follow the library
+// version that loads them. Settings that change builtin patterns or add
+// patterns of their own are not part of the spec and must be set on the
+// new engine's Config:
+//
+//   - Strictness, RequireCues, UnicodeBoundaries, AggressiveBoundaries and
+//     AggressivePatterns
+//   - CCSkipLuhn, CCFragments (CC_FRAGMENT is never exported), CCWrapped
+//     and LuhnExemptPrefixes
+//   - SkipIPVersions, SkipCurrency, DateOrder and AgeThreshold
+//   - URLAware, Base64Aware, RedactDisplayNames, ObfuscatedEmails,
+//     SpokenDigits and SpokenFillers
+//   - NormalizeWhitespace, PDFLayout, LanguagePatterns and Detectors
+//
+// It returns an error wrapping ErrNotPortable if a custom pattern has no
+// regex, a ContextValidate function, or a validator that is not
+// registered.
+func (e *RedactionEngine) ExportSpec() ([]byte, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	c := e.config
+	keep := func(p PatternDef) bool {
+		return c.MinSensitivity == SensitivityUnspecified || p.Sensitivity.effective() >= c.MinSensitivity
+	}
+	minLength := func(p PatternDef) int {
+		if n, ok := c.MinLength[p.Name]; ok {
+			return n
+		}
+		return p.MinLength
+	}
+
+	specs := []PatternSpec{}
+	for _, p := range builtinPatterns {
+		if builtinEnabled(c, p.Name, false) && keep(p) {
+			specs = append(specs, PatternSpec{Name: p.Name, Builtin: true, MinLength: minLength(p)})
+		}
+	}
+	for _, p := range optionalPatterns {
+		if builtinEnabled(c, p.Name, true) && keep(p) {
+			specs = append(specs, PatternSpec{Name: p.Name, Builtin: true, MinLength: minLength(p)})
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		terms []string
+	}{{"NAME", c.NameDictionary}, {"DENYLIST", c.Denylist}} {
+		if p, ok := newDictionaryPattern(d.name, d.terms); ok {
+			specs = append(specs, PatternSpec{Name: d.name, Terms: p.terms, Priority: c.LiteralPriority, MinLength: minLength(p)})
+		}
+	}
+
+	for _, p := range c.CustomPatterns {
+		if !keep(p) {
+			continue
+		}
+		spec, err := customSpec(p)
+		if err != nil {
+			return nil, err
+		}
+		spec.MinLength = minLength(p)
+		specs = append(specs, spec)
+	}
+
+	return json.MarshalIndent(specs, "", "  ")
+}
+
+// customSpec describes a custom pattern. Builtin patterns passed back in
+// as custom patterns, as LoadPatterns returns them, are recognized by
+// their shared regex and exported by name.
+func customSpec(p PatternDef) (PatternSpec, error) {
+	for _, b := range slices.Concat(builtinPatterns, optionalPatterns) {
+		if b.Name == p.Name && b.Regex == p.Regex {
+			return PatternSpec{Name: p.Name, Builtin: true}, nil
+		}
+	}
+
+	if p.terms != nil {
+		return PatternSpec{Name: p.Name, Terms: p.terms, Priority: p.Priority, Description: p.Description}, nil
+	}
+	if p.Regex == nil || p.find != nil || p.detector != nil || p.rewrite != nil {
+		return PatternSpec{}, fmt.Errorf("%w: %s has no regex", ErrNotPortable, p.Name)
+	}
+	if p.ContextValidate != nil {
+		return PatternSpec{}, fmt.Errorf("%w: %s has a ContextValidate function", ErrNotPortable, p.Name)
+	}
+
+	spec := PatternSpec{Name: p.Name, Regex: p.Regex.String(), Priority: p.Priority, Sensitivity: p.Sensitivity, GroupID: p.GroupID, Confidence: p.Confidence, Requires: p.Requires, Description: p.Description}
+	if p.Validate != nil {
+		name, ok := validatorName(p.Validate)
+		if !ok {
+			return PatternSpec{}, fmt.Errorf("%w: %s has an unregistered validator", ErrNotPortable, p.Name)
+		}
+		spec.Validator = name
+	}
+	return spec, nil
+}
+
+// LoadPatterns builds patterns from JSON written by ExportSpec.
+//
+// The patterns are meant for Config.CustomPatterns together with
+// DisableBuiltins, which rebuilds the exported engine's pattern list in
+// its original order:
+//
+//	patterns, err := piiredact.LoadPatterns(spec)
+//	config := piiredact.DefaultConfig()
+//	config.DisableBuiltins = true
+//	config.CustomPatterns = patterns
+//
+// A builtin AGE pattern uses the default threshold. It returns an error if
+// the JSON is malformed, a builtin or validator name is unknown, or a regex
+// does not compile.
+func LoadPatterns(data []byte) ([]PatternDef, error) {
+	var specs []PatternSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("piiredact: parsing pattern spec: %w", err)
+	}
+
+	patterns := make([]PatternDef, 0, len(specs))
+	for _, spec := range specs {
+		p, err := spec.pattern()
+		if err != nil {
+			return nil, err
+		}
+		if spec.MinLength != 0 {
+			p.MinLength = spec.MinLength
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// pattern builds the pattern a spec describes.
+func (spec PatternSpec) pattern() (PatternDef, error) {
+	switch {
+	case spec.Builtin:
+		for _, p := range slices.Concat(builtinPatterns, optionalPatterns) {
+			if p.Name == spec.Name {
+				if p.Name == "AGE" {
+					p = agePattern(p, defaultAgeThreshold)
+				}
+				return p, nil
+			}
+		}
+		return PatternDef{}, fmt.Errorf("%w: %s", ErrUnknownPattern, spec.Name)
+
+	case len(spec.Terms) > 0:
+		p, ok := newDictionaryPattern(spec.Name, spec.Terms)
+		if !ok {
+			return PatternDef{}, fmt.Errorf("piiredact: pattern %s has no usable terms", spec.Name)
+		}
+		p.Priority = spec.Priority
+		p.Description = spec.Description
+		return p, nil
+	}
+
+	re, err := regexp.Compile(spec.Regex)
+	if err != nil {
+		return PatternDef{}, fmt.Errorf("piiredact: pattern %s: %w", spec.Name, err)
+	}
+	p := PatternDef{Name: spec.Name, Regex: re, Priority: spec.Priority, Sensitivity: spec.Sensitivity, GroupID: spec.GroupID, Confidence: spec.Confidence, Requires: spec.Requires, Description: spec.Description}
+	if spec.Validator != "" {
+		validators.RLock()
+		p.Validate = validators.byName[spec.Validator]
+		validators.RUnlock()
+		if p.Validate == nil {
+			return PatternDef{}, fmt.Errorf("piiredact: pattern %s: unknown validator %q", spec.Name, spec.Validator)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,81 @@
+package piiredact
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestExportSpec tests round-tripping an engine's patterns through LoadPatterns
+func TestExportSpec(t *testing.T) {
+	employee := func(id string) bool { return !strings.HasSuffix(id, "000000") }
+	RegisterValidator("employee", employee)
+
+	config := DefaultConfig()
+	config.EnabledPatterns = nil
+	config.NameDictionary = []string{"Alex", "Jane Doe"}
+	config.MinLength = map[string]int{"EMAIL": 10}
+	config.CustomPatterns = []PatternDef{
+		{Name: "EMPLOYEE_ID", Regex: regexp.MustCompile(`\bEMP-\d{6}\b`), Validate: employee, Priority: 2},
+		{Name: "CARD_REF", Regex: regexp.MustCompile(`\bREF\d{16}\b`), Validate: validateLuhn},
+	}
+	original := NewRedactionEngine(config)
+
+	spec, err := original.ExportSpec()
+	if err != nil {
+		t.Fatalf("ExportSpec returned error: %v", err)
+	}
+	for _, want := range []string{`"name": "SSN"`, `"builtin": true`, `"terms": [`, `"validator": "employee"`, `"validator": "luhn"`, `"min_length": 10`} {
+		if !strings.Contains(string(spec), want) {
+			t.Errorf("Spec does not contain %s:\n%s", want, spec)
+		}
+	}
+
+	patterns, err := LoadPatterns(spec)
+	if err != nil {
+		t.Fatalf("LoadPatterns returned error: %v", err)
+	}
+	loaded := DefaultConfig()
+	loaded.DisableBuiltins = true
+	loaded.CustomPatterns = patterns
+	rebuilt := NewRedactionEngine(loaded)
+
+	text := realisticChunk + " Jane Doe has EMP-123456, not EMP-000000, and a@b.co wrote."
+	a, _ := original.Process([]Chunk{{UUID: "u", Speaker: "A", Text: text}})
+	b, _ := rebuilt.Process([]Chunk{{UUID: "u", Speaker: "A", Text: text}})
+	if a[0].Text != b[0].Text {
+		t.Errorf("Rebuilt engine differs:\nOriginal: %s\nRebuilt:  %s", a[0].Text, b[0].Text)
+	}
+
+	// The rebuilt engine exports the same spec
+	again, err := rebuilt.ExportSpec()
+	if err != nil {
+		t.Fatalf("ExportSpec of rebuilt engine returned error: %v", err)
+	}
+	if string(again) != string(spec) {
+		t.Errorf("Re-exported spec differs:\n%s\n%s", spec, again)
+	}
+}
+
+// TestExportSpec_Errors tests patterns that cannot be exported or loaded
+func TestExportSpec_Errors(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{
+		{Name: "ODD", Regex: regexp.MustCompile(`\d+`), Validate: func(s string) bool { return len(s)%2 == 1 }},
+	}
+	if _, err := NewRedactionEngine(config).ExportSpec(); !errors.Is(err, ErrNotPortable) {
+		t.Errorf("Expected ErrNotPortable for an unregistered validator, got %v", err)
+	}
+
+	for _, spec := range []string{
+		`not json`,
+		`[{"name": "NOPE", "builtin": true}]`,
+		`[{"name": "X", "regex": "("}]`,
+		`[{"name": "X", "regex": "x", "validator": "missing"}]`,
+	} {
+		if _, err := LoadPatterns([]byte(spec)); err == nil {
+			t.Errorf("LoadPatterns(%s) should fail", spec)
+		}
+	}
+}
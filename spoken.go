@@ -0,0 +1,175 @@
+package piiredact
+
+import (
+	"strings"
+)
+
+// digitWords maps spoken digit words to their digits.
+var digitWords = map[string]byte{
+	"zero": '0', "oh": '0', "o": '0',
+	"one": '1', "two": '2', "three": '3', "four": '4', "five": '5',
+	"six": '6', "seven": '7', "eight": '8', "nine": '9',
+}
+
+// fillerWords are hesitations that SpokenFillers skips inside a digit run.
+var fillerWords = map[string]bool{
+	"um": true, "umm": true, "uh": true, "uhm": true, "er": true, "erm": true,
+	"ah": true, "hmm": true, "mm": true, "like": true,
+}
+
+// repeatWords multiply the digit that follows them, as in "double five".
+var repeatWords = map[string]int{"double": 2, "triple": 3}
+
+// spokenDigit is one digit of a spoken number and the span of text it came from.
+type spokenDigit struct {
+	digit      byte // ASCII digit
+	start, end int  // Byte offsets of the word or numeral that produced it
+	spelled    bool // Whether it was written as a word rather than a numeral
+	hesitant   bool // Whether a filler word came between it and the digit before
+}
+
+// spokenDigitsDetector finds phone numbers and SSNs written with digit
+// words, as raw ASR output often does: "four oh four five five five one
+// two one two" or "my social is one two three, four five, six seven
+// eight nine".
+//
+// Consecutive digit words and numerals, separated only by whitespace,
+// hyphens or commas, form a run; "double" and "triple" repeat the next
+// digit. Each run is scanned left to right for a ten or eleven digit
+// number that passes validateNANP, then a nine digit one that passes
+// validateSSN, and the words behind a hit are redacted under the PHONE or
+// SSN label. A hit must include at least one spelled-out word, so plain
+// numerals are left to the regular patterns.
+//
+// Limitations: only English single-digit words are understood, so numbers
+// read in groups ("four oh four, fifty-five fifty-five") are not
+// recognized; any other word, including fillers such as "uh" unless
+// fillers is set, ends a run; and a run longer than one value is split
+// greedily, so a value that starts mid-run may be found at the wrong offset
+// or missed.
+//
+// With fillers set (Config.SpokenFillers), filler words such as "um",
+// "uh" and "like" are skipped instead of ending a run, so "1 2 3 um 4 5 6
+// 7 8 9" is one run. The redacted span still runs from the first digit to
+// the last, fillers included. A hit interrupted by a filler need not
+// include a spelled-out word, since the regular patterns cannot match it.
+type spokenDigitsDetector struct {
+	phone, ssn bool // Which labels to detect, following the enabled patterns
+	fillers    bool // Skip filler words inside runs rather than ending them
+}
+
+// Detect implements Detector.
+func (d spokenDigitsDetector) Detect(text string) ([]Detection, error) {
+	var detections []Detection
+	for _, run := range spokenDigitRuns(text, d.fillers) {
+		for i := 0; i < len(run); {
+			n, name := d.valueAt(run[i:])
+			if n == 0 {
+				i++
+				continue
+			}
+			start, end := run[i].start, run[i+n-1].end
+			detections = append(detections, Detection{PatternName: name, Start: start, End: end, Value: text[start:end]})
+			i += n
+		}
+	}
+	return detections, nil
+}
+
+// valueAt returns the length and label of the value at the start of run,
+// or zero if there is none.
+func (d spokenDigitsDetector) valueAt(run []spokenDigit) (int, string) {
+	candidates := []struct {
+		n       int
+		name    string
+		enabled bool
+		valid   func(string) bool
+	}{
+		{11, "PHONE", d.phone, validateNANP},
+		{10, "PHONE", d.phone, validateNANP},
+		{9, "SSN", d.ssn, validateSSN},
+	}
+
+	for _, c := range candidates {
+		if !c.enabled || len(run) < c.n {
+			continue
+		}
+		digits := make([]byte, c.n)
+		spelled := false
+		for i, sd := range run[:c.n] {
+			digits[i] = sd.digit
+			spelled = spelled || sd.spelled || (i > 0 && sd.hesitant)
+		}
+		if spelled && c.valid(string(digits)) {
+			return c.n, c.name
+		}
+	}
+	return 0, ""
+}
+
+// spokenDigitRuns splits text into runs of consecutive digits, spoken or
+// written as numerals. With fillers set, filler words do not end a run.
+func spokenDigitRuns(text string, fillers bool) [][]spokenDigit {
+	var runs [][]spokenDigit
+	var run []spokenDigit
+	hesitant := false
+	flush := func() {
+		if len(run) > 0 {
+			runs = append(runs, run)
+			run = nil
+		}
+		hesitant = false
+	}
+	add := func(sd spokenDigit) {
+		sd.hesitant = hesitant
+		run = append(run, sd)
+		hesitant = false
+	}
+
+	repeat, repeatStart := 0, 0
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c >= '0' && c <= '9':
+			for ; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+				add(spokenDigit{digit: text[i], start: i, end: i + 1})
+			}
+			repeat = 0
+
+		case isASCIILetter(rune(c)):
+			j := i
+			for j < len(text) && isASCIILetter(rune(text[j])) {
+				j++
+			}
+			word := strings.ToLower(text[i:j])
+			if digit, ok := digitWords[word]; ok {
+				start, n := i, 1
+				if repeat > 0 {
+					start, n = repeatStart, repeat
+				}
+				for ; n > 0; n-- {
+					add(spokenDigit{digit: digit, start: start, end: j, spelled: true})
+				}
+				repeat = 0
+			} else if n, ok := repeatWords[word]; ok {
+				repeat, repeatStart = n, i
+			} else if fillers && fillerWords[word] && len(run) > 0 {
+				hesitant = true
+			} else {
+				flush()
+				repeat = 0
+			}
+			i = j
+
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '-' || c == ',':
+			i++
+
+		default:
+			flush()
+			repeat = 0
+			i++
+		}
+	}
+	flush()
+	return runs
+}
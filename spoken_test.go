@@ -0,0 +1,92 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_SpokenDigits tests phone numbers and SSNs written as digit words
+func TestRedactionEngine_SpokenDigits(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"call me at four oh four five five five one two one two thanks", "call me at [PHONE] thanks"},
+		{"it's Four-Oh-Four, 555, one two one two.", "it's [PHONE]."},
+		{"one eight hundred", "one eight hundred"},
+		{"the number is one four oh four double five five one two one two", "the number is [PHONE]"},
+		{"my social is one two three, four five, six seven eight nine", "my social is [SSN]"},
+		// Invalid values and plain numerals are left to the regular patterns
+		{"zero zero zero one two three four five six", "zero zero zero one two three four five six"},
+		{"order 4 0 4 5 5 5 1 2 1 2", "order 4 0 4 5 5 5 1 2 1 2"},
+		// Any other word ends the run
+		{"four oh four uh five five five one two one two", "four oh four uh five five five one two one two"},
+		{"I have one question and two answers", "I have one question and two answers"},
+	}
+
+	config := DefaultConfig()
+	config.SpokenDigits = true
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// Without the flag, or with the pattern disabled, nothing is detected
+	input := "call four oh four five five five one two one two"
+	config.EnabledPatterns = map[string]bool{"EMAIL": true}
+	for _, engine := range []*RedactionEngine{NewRedactionEngine(DefaultConfig()), NewRedactionEngine(config)} {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+		if result[0].Text != input {
+			t.Errorf("Expected %q unchanged, got %q", input, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_SpokenFillers tests spoken SSNs interrupted by filler words
+func TestRedactionEngine_SpokenFillers(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"it's 1 2 3 um 4 5 6 7 8 9 okay", "it's [SSN] okay"},
+		{"my social is one two three, uh, four five, like, six seven eight nine", "my social is [SSN]"},
+		{"SSN 123 um 45 uh 6789.", "SSN [SSN]."},
+		{"one two three four five six seven eight nine", "[SSN]"},
+		// A filler before the first digit is not part of the value
+		{"um 1 2 3 4 5 6 7 8 9", "um 1 2 3 4 5 6 7 8 9"},
+		// Invalid values and other words are left alone
+		{"0 0 0 um 1 2 3 4 5 6", "0 0 0 um 1 2 3 4 5 6"},
+		{"1 2 3 and 4 5 6 7 8 9", "1 2 3 and 4 5 6 7 8 9"},
+		{"I like 5 apples", "I like 5 apples"},
+	}
+
+	config := DefaultConfig()
+	config.SpokenFillers = true
+	engine := NewRedactionEngine(config)
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+
+	// With SpokenDigits too, fillers are skipped in phone numbers
+	config.SpokenDigits = true
+	engine = NewRedactionEngine(config)
+	input := "call four oh four um five five five, uh, one two one two"
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+	if expected := "call [PHONE]"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+
+	// The detection covers the original span, fillers included
+	text := "it's 1 2 3 um 4 5 6 7 8 9 okay"
+	detections, _ := engine.Detect(text)
+	if len(detections) != 1 || detections[0].Value != "1 2 3 um 4 5 6 7 8 9" {
+		t.Errorf("Expected one detection of the whole span, got %+v", detections)
+	}
+}
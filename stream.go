@@ -0,0 +1,117 @@
+package piiredact
+
+import (
+	"context"
+	"time"
+)
+
+// streamJob is a chunk in flight through ProcessStream together with the
+// channel its result is delivered on.
+type streamJob struct {
+	chunk  Chunk      // Chunk to be redacted
+	result chan Chunk // Buffered; receives the redacted chunk exactly once
+}
+
+// ProcessStream redacts chunks read from in and delivers them, in input
+// order, on the returned channel.
+//
+// Chunks are redacted by a pool of MaxConcurrency workers. At most that
+// many chunks are in flight at once, so a slow consumer of the output
+// channel slows down reads from in rather than growing a buffer. The output
+// channel is closed once in is closed and drained, or as soon as ctx is
+// cancelled; chunks still in flight at cancellation are dropped. With
+// DropEmptyChunks, empty chunks are consumed without being emitted.
+//
+// Metrics are updated per chunk. ProcessStream has no error result, so
+// audit and token sidecar write failures are reported by the next call to
+// Process.
+func (e *RedactionEngine) ProcessStream(ctx context.Context, in <-chan Chunk) <-chan Chunk {
+	e.configMu.RLock()
+	workers := e.config.MaxConcurrency
+	e.configMu.RUnlock()
+	if workers <= 0 {
+		workers = 8 // Fallback to default if invalid
+	}
+
+	out := make(chan Chunk)
+	jobs := make(chan streamJob)
+	pending := make(chan chan Chunk, workers) // Result channels in input order
+
+	// Start the workers; each one drains the jobs channel until it is closed
+	e.streams.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer e.streams.Done()
+			for job := range jobs {
+				startTime := time.Now()
+				e.configMu.RLock()
+				redacted := e.redactChunk(job.chunk)
+				e.configMu.RUnlock()
+				job.result <- redacted
+
+				e.metrics.mu.Lock()
+				e.metrics.ProcessedChunks++
+				e.metrics.ProcessingTimeNs += time.Since(startTime).Nanoseconds()
+				e.metrics.mu.Unlock()
+			}
+		}()
+	}
+
+	// Read the input, queueing each chunk's result slot before handing the
+	// chunk to a worker so the collector sees slots in input order
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for {
+			var chunk Chunk
+			select {
+			case <-ctx.Done():
+				return
+			case c, ok := <-in:
+				if !ok {
+					return
+				}
+				chunk = c
+			}
+			e.configMu.RLock()
+			drop := e.config.DropEmptyChunks
+			e.configMu.RUnlock()
+			if drop && isEmptyChunk(chunk) {
+				e.countSkippedEmpty()
+				continue
+			}
+
+			result := make(chan Chunk, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- streamJob{chunk: chunk, result: result}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Collect results in input order
+	go func() {
+		defer close(out)
+		for result := range pending {
+			var chunk Chunk
+			select {
+			case chunk = <-result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,66 @@
+package piiredact
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestRedactionEngine_ProcessStream tests that streamed chunks are redacted in order
+func TestRedactionEngine_ProcessStream(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxConcurrency = 4
+	engine := NewRedactionEngine(config)
+
+	const n = 200
+	in := make(chan Chunk)
+	go func() {
+		defer close(in)
+		for i := 0; i < n; i++ {
+			in <- Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: "SSN 123-45-6789"}
+		}
+	}()
+
+	i := 0
+	for c := range engine.ProcessStream(context.Background(), in) {
+		if expected := fmt.Sprintf("id%d", i); c.UUID != expected {
+			t.Fatalf("Chunk %d out of order: got %s", i, c.UUID)
+		}
+		if c.Text != "SSN [SSN]" {
+			t.Errorf("Chunk %s not redacted: %s", c.UUID, c.Text)
+		}
+		i++
+	}
+	if i != n {
+		t.Errorf("Expected %d chunks, got %d", n, i)
+	}
+
+	if metrics := engine.GetMetrics(); metrics.ProcessedChunks != n || metrics.RedactedItems["SSN"] != n {
+		t.Errorf("Unexpected metrics: %d chunks, %d SSNs", metrics.ProcessedChunks, metrics.RedactedItems["SSN"])
+	}
+}
+
+// TestRedactionEngine_ProcessStreamCancel tests that cancelling closes the output
+func TestRedactionEngine_ProcessStreamCancel(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan Chunk) // Never closed
+	out := engine.ProcessStream(ctx, in)
+
+	in <- Chunk{UUID: "id1", Text: "call 404-555-1212"}
+	if c := <-out; c.Text != "call [PHONE]" {
+		t.Errorf("Unexpected chunk: %s", c.Text)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("Expected output channel to be closed after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Output channel not closed after cancel")
+	}
+}
@@ -0,0 +1,62 @@
+package piiredact
+
+// Strictness is a preset that trades recall for precision by setting
+// several Config knobs at once.
+//
+// A preset only fills in knobs left at their zero value, so anything set
+// explicitly still wins: for example, a RequireCues entry set to false keeps
+// that pattern ungated under StrictnessStrict.
+type Strictness int
+
+const (
+	// StrictnessBalanced, the zero value, changes nothing: the engine runs
+	// with the knobs exactly as configured.
+	StrictnessBalanced Strictness = iota
+
+	// StrictnessLenient favours recall. It sets AggressiveBoundaries, so
+	// values glued to surrounding words in ASR output are still found by
+	// the patterns that have a validator (or those in AggressivePatterns).
+	StrictnessLenient
+
+	// StrictnessStrict favours precision. It sets UnicodeBoundaries, so
+	// matches inside accented words are rejected; sets RequireCues for
+	// every enabled pattern that has cue words, currently IMEI; and
+	// validates PHONE matches against North American numbering rules, so
+	// numbers such as "055-123-4567" or "911-555-1212" are left alone.
+	StrictnessStrict
+)
+
+// applyStrictness returns config with the knobs of its Strictness preset
+// filled in. The caller's maps are copied rather than modified.
+func applyStrictness(config Config) Config {
+	switch config.Strictness {
+	case StrictnessLenient:
+		config.AggressiveBoundaries = true
+
+	case StrictnessStrict:
+		config.UnicodeBoundaries = true
+
+		cues := make(map[string]bool, len(config.RequireCues))
+		for _, p := range optionalPatterns {
+			if len(p.cues) > 0 {
+				cues[p.Name] = true
+			}
+		}
+		for _, p := range builtinPatterns {
+			if len(p.cues) > 0 {
+				cues[p.Name] = true
+			}
+		}
+		for name, required := range config.RequireCues {
+			cues[name] = required
+		}
+		config.RequireCues = cues
+	}
+	return config
+}
+
+// strictValidators are the validators StrictnessStrict adds to builtin
+// patterns that have none of their own.
+var strictValidators = map[string]func(string) bool{
+	"PHONE": validateNANP,
+}
@@ -0,0 +1,90 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestValidateNANP tests North American phone number rules
+func TestValidateNANP(t *testing.T) {
+	testCases := []struct {
+		phone string
+		valid bool
+	}{
+		{"404-555-1212", true},
+		{"(404) 555-1212", true},
+		{"+1 404 555 1212", true},
+		{"404-555-1212 ext. 12", true},
+		{"055-123-4567", false}, // Area code starts with 0
+		{"404-155-1212", false}, // Exchange starts with 1
+		{"911-555-1212", false}, // N11 service code
+		{"555-1212", false},
+	}
+
+	for _, tc := range testCases {
+		if got := validateNANP(tc.phone); got != tc.valid {
+			t.Errorf("validateNANP(%q) = %v, expected %v", tc.phone, got, tc.valid)
+		}
+	}
+}
+
+// TestRedactionEngine_Strictness tests the knobs set by each preset
+func TestRedactionEngine_Strictness(t *testing.T) {
+	inputs := []string{
+		"ssn123-45-6789 please",
+		"call 055-123-4567 now",
+		"josé.garcía@example.com",
+		"Order 490154203237518 shipped",
+	}
+
+	testCases := []struct {
+		strictness Strictness
+		expected   []string
+	}{
+		{StrictnessBalanced, []string{
+			"ssn123-45-6789 please",
+			"call [PHONE] now",
+			"josé.garcí[EMAIL]",
+			"Order [IMEI] shipped",
+		}},
+		{StrictnessLenient, []string{
+			"ssn[SSN] please",
+			"call [PHONE] now",
+			"josé.garcí[EMAIL]",
+			"Order [IMEI] shipped",
+		}},
+		{StrictnessStrict, []string{
+			"ssn123-45-6789 please",
+			"call 055-123-4567 now",
+			"[EMAIL]",
+			"Order 490154203237518 shipped",
+		}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.EnabledPatterns["IMEI"] = true
+		config.Strictness = tc.strictness
+		engine := NewRedactionEngine(config)
+
+		for i, input := range inputs {
+			result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+			if result[0].Text != tc.expected[i] {
+				t.Errorf("Strictness %d: Input: %s\nExpected: %s\nGot: %s", tc.strictness, input, tc.expected[i], result[0].Text)
+			}
+		}
+	}
+
+	// Explicit settings win over the preset, and the caller's map is untouched
+	config := DefaultConfig()
+	config.EnabledPatterns["IMEI"] = true
+	config.Strictness = StrictnessStrict
+	config.RequireCues = map[string]bool{"IMEI": false}
+	engine := NewRedactionEngine(config)
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: inputs[3]}})
+	if expected := "Order [IMEI] shipped"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+	if len(config.RequireCues) != 1 {
+		t.Errorf("RequireCues was modified: %v", config.RequireCues)
+	}
+}
@@ -0,0 +1,76 @@
+package piiredact
+
+import (
+	"sort"
+	"strconv"
+)
+
+// keyChunkUUID labels audit records and logs for redacted map keys, which
+// cannot be labelled with the key itself.
+const keyChunkUUID = "key"
+
+// MapOptions controls RedactMap.
+type MapOptions struct {
+	Keys []string // Only redact values under these keys (exact match); empty redacts every value
+}
+
+// RedactMap returns a copy of m with PII redacted from its values.
+//
+// Each value is redacted as its own chunk, labelled with its key in audit
+// records and logs. Values whose keys are not listed in opts.Keys are copied
+// unchanged.
+//
+// Keys are only scanned when Config.RedactKeys is set; opts.Keys is always
+// matched against the original keys. If two keys redact to the same text,
+// later ones (in sorted order of the original keys) get a "_2", "_3", ...
+// suffix so no value is lost. Like ProcessStream, RedactMap has no error
+// result; audit and token sidecar write failures are reported by the next
+// call to Process.
+func (e *RedactionEngine) RedactMap(m map[string]string, opts MapOptions) map[string]string {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	var only map[string]bool
+	if len(opts.Keys) > 0 {
+		only = make(map[string]bool, len(opts.Keys))
+		for _, k := range opts.Keys {
+			only[k] = true
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(m))
+	for _, k := range keys {
+		v := m[k]
+		outKey := k
+		if e.config.RedactKeys {
+			outKey = e.redactChunk(Chunk{UUID: keyChunkUUID, Text: k}).Text
+			outKey = uniqueKey(result, outKey)
+		}
+
+		if only != nil && !only[k] {
+			result[outKey] = v
+			continue
+		}
+		result[outKey] = e.redactChunk(Chunk{UUID: outKey, Text: v}).Text
+	}
+	return result
+}
+
+// uniqueKey returns key, or key with the first free "_N" suffix if it is
+// already present in m.
+func uniqueKey(m map[string]string, key string) string {
+	if _, taken := m[key]; !taken {
+		return key
+	}
+	for n := 2; ; n++ {
+		candidate := key + "_" + strconv.Itoa(n)
+		if _, taken := m[candidate]; !taken {
+			return candidate
+		}
+	}
+}
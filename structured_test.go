@@ -0,0 +1,78 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_RedactMap tests redacting the values of a flat map
+func TestRedactionEngine_RedactMap(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	input := map[string]string{
+		"email": "jane@example.com",
+		"note":  "call 404-555-1212",
+		"ssn":   "123-45-6789",
+		"count": "42",
+	}
+
+	result := engine.RedactMap(input, MapOptions{})
+	expected := map[string]string{
+		"email": "[EMAIL]",
+		"note":  "call [PHONE]",
+		"ssn":   "[SSN]",
+		"count": "42",
+	}
+	for k, v := range expected {
+		if result[k] != v {
+			t.Errorf("Key %s: expected %q, got %q", k, v, result[k])
+		}
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d keys, got %d", len(expected), len(result))
+	}
+	if input["ssn"] != "123-45-6789" {
+		t.Error("RedactMap modified its input")
+	}
+
+	// Only the listed keys are redacted
+	result = engine.RedactMap(input, MapOptions{Keys: []string{"email", "ssn"}})
+	if result["email"] != "[EMAIL]" || result["ssn"] != "[SSN]" || result["note"] != "call 404-555-1212" {
+		t.Errorf("Unexpected result with key filter: %v", result)
+	}
+}
+
+// TestRedactionEngine_RedactMapKeys tests redacting PII found in map keys
+func TestRedactionEngine_RedactMapKeys(t *testing.T) {
+	input := map[string]string{
+		"john@x.com":   "yes",
+		"jane@x.com":   "no",
+		"X-Request-Id": "abc",
+	}
+
+	// Keys are left alone by default
+	engine := NewRedactionEngine(DefaultConfig())
+	result := engine.RedactMap(input, MapOptions{})
+	if result["john@x.com"] != "yes" {
+		t.Errorf("Keys should not be redacted by default: %v", result)
+	}
+
+	config := DefaultConfig()
+	config.RedactKeys = true
+	engine = NewRedactionEngine(config)
+	result = engine.RedactMap(input, MapOptions{})
+
+	// Colliding redacted keys are numbered in sorted order of the originals
+	expected := map[string]string{
+		"[EMAIL]":      "no",
+		"[EMAIL]_2":    "yes",
+		"X-Request-Id": "abc",
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+	for k, v := range expected {
+		if result[k] != v {
+			t.Errorf("Key %s: expected %q, got %q", k, v, result[k])
+		}
+	}
+}
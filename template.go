@@ -0,0 +1,99 @@
+package piiredact
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TemplatePattern builds a pattern from a format template, so institutional
+// IDs can be redacted without writing a regex. For example
+//
+//	p, err := piiredact.TemplatePattern("CASE_ID", "CAS-YYYY-#######")
+//
+// matches "CAS-2024-0001234". Templates are read as follows:
+//
+//   - '#' matches one digit.
+//   - A run of letters made up only of 'A' matches that many letters, as in
+//     "AA-####".
+//   - The letter run "YYYY" matches a year from 1900 to 2099.
+//   - Any other letter run, and any other character, is matched literally,
+//     so the "A" in "CAS" is part of the literal "CAS".
+//   - A backslash makes the next character literal, as in "\A-###".
+//
+// Literal segments are case-sensitive. The pattern only matches on word
+// boundaries.
+func TemplatePattern(name, template string) (PatternDef, error) {
+	if name == "" {
+		return PatternDef{}, errors.New("piiredact: template pattern needs a name")
+	}
+	if template == "" {
+		return PatternDef{}, errors.New("piiredact: empty template")
+	}
+
+	var expr strings.Builder
+	runes := []rune(template)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) {
+				return PatternDef{}, fmt.Errorf("piiredact: template %q ends with a backslash", template)
+			}
+			expr.WriteString(regexp.QuoteMeta(string(runes[i+1])))
+			i += 2
+
+		case r == '#':
+			n := runLength(runes, i, func(r rune) bool { return r == '#' })
+			fmt.Fprintf(&expr, "[0-9]{%d}", n)
+			i += n
+
+		case isASCIILetter(r):
+			n := runLength(runes, i, isASCIILetter)
+			word := string(runes[i : i+n])
+			switch {
+			case word == "YYYY":
+				expr.WriteString("(?:19|20)[0-9]{2}")
+			case strings.Trim(word, "A") == "":
+				fmt.Fprintf(&expr, "[A-Za-z]{%d}", n)
+			default:
+				expr.WriteString(regexp.QuoteMeta(word))
+			}
+			i += n
+
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			i++
+		}
+	}
+
+	// Anchor on word boundaries where the template starts or ends with a word character
+	pattern := expr.String()
+	if isWordRune(runes[0]) || runes[0] == '#' {
+		pattern = `\b` + pattern
+	}
+	if last := runes[len(runes)-1]; isWordRune(last) || last == '#' {
+		pattern += `\b`
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return PatternDef{}, fmt.Errorf("piiredact: compiling template %q: %w", template, err)
+	}
+	return PatternDef{Name: name, Regex: re}, nil
+}
+
+// runLength counts the runes from runes[i] onward that satisfy in.
+func runLength(runes []rune, i int, in func(rune) bool) int {
+	n := 0
+	for i+n < len(runes) && in(runes[i+n]) {
+		n++
+	}
+	return n
+}
+
+// isASCIILetter reports whether r is an ASCII letter.
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
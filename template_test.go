@@ -0,0 +1,59 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestTemplatePattern tests compiling ID templates into patterns
+func TestTemplatePattern(t *testing.T) {
+	testCases := []struct {
+		template string
+		matches  []string
+		rejects  []string
+	}{
+		{"CAS-YYYY-#######", []string{"CAS-2024-0001234", "CAS-1999-9999999"},
+			[]string{"CAS-2024-001234", "CAS-1850-0001234", "CBS-2024-0001234", "XCAS-2024-0001234"}},
+		{"AA-####", []string{"AB-1234", "zz-0000"}, []string{"A1-1234", "ABC-1234"}},
+		{`\A-###`, []string{"A-123"}, []string{"B-123"}},
+		{"MRN.#####", []string{"MRN.12345"}, []string{"MRNX12345"}},
+	}
+
+	for _, tc := range testCases {
+		p, err := TemplatePattern("ID", tc.template)
+		if err != nil {
+			t.Fatalf("TemplatePattern(%q) returned error: %v", tc.template, err)
+		}
+		for _, s := range tc.matches {
+			if loc := p.Regex.FindStringIndex(s); loc == nil || loc[0] != 0 || loc[1] != len(s) {
+				t.Errorf("Template %q (%s) should match %q", tc.template, p.Regex, s)
+			}
+		}
+		for _, s := range tc.rejects {
+			if p.Regex.MatchString(s) {
+				t.Errorf("Template %q (%s) should not match %q", tc.template, p.Regex, s)
+			}
+		}
+	}
+
+	for _, bad := range []string{"", `ID-\`} {
+		if _, err := TemplatePattern("ID", bad); err == nil {
+			t.Errorf("Expected error for template %q", bad)
+		}
+	}
+}
+
+// TestRedactionEngine_TemplatePattern tests redacting with a template pattern
+func TestRedactionEngine_TemplatePattern(t *testing.T) {
+	p, err := TemplatePattern("CASE_ID", "CAS-YYYY-#######")
+	if err != nil {
+		t.Fatalf("TemplatePattern returned error: %v", err)
+	}
+	config := DefaultConfig()
+	config.CustomPatterns = []PatternDef{p}
+	engine := NewRedactionEngine(config)
+
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: "See case CAS-2024-0001234 for details"}})
+	if expected := "See case [CASE_ID] for details"; result[0].Text != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result[0].Text)
+	}
+}
@@ -0,0 +1,50 @@
+package piiredact
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttling.
+//
+// Config.MaxChunksPerSecond caps how fast the engine starts redacting
+// chunks, so that a rate-limited dependency such as an NER service is not
+// overwhelmed. The cap is shared by all concurrent calls on the engine,
+// including Process, ProcessStream and the format-specific methods, and
+// spaces chunk starts evenly, without bursts: a batch of n chunks takes at
+// least (n-1)/MaxChunksPerSecond seconds. Empty chunks, which are skipped
+// without detection, are not throttled.
+
+// rateLimiter spaces events at least interval apart.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Minimum time between events
+	next     time.Time     // Earliest time of the next event
+}
+
+// newRateLimiter returns a limiter allowing perSecond events a second, or
+// nil (no limit) if perSecond is not positive.
+func newRateLimiter(perSecond int) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// wait blocks until the next event is allowed. A nil limiter never blocks.
+func (l *rateLimiter) wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
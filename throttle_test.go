@@ -0,0 +1,43 @@
+package piiredact
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestRedactionEngine_MaxChunksPerSecond tests throttling Process to a target rate
+func TestRedactionEngine_MaxChunksPerSecond(t *testing.T) {
+	chunks := make([]Chunk, 11)
+	for i := range chunks {
+		chunks[i] = Chunk{UUID: fmt.Sprintf("id%d", i), Speaker: "A", Text: "SSN 401-23-4567"}
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		config := DefaultConfig()
+		config.MaxConcurrency = concurrency
+		config.MaxChunksPerSecond = 50
+		engine := NewRedactionEngine(config)
+
+		// Eleven chunks at 50 per second start 20ms apart, taking at least 200ms
+		start := time.Now()
+		result, err := engine.Process(chunks)
+		elapsed := time.Since(start)
+		if err != nil {
+			t.Fatalf("Process returned error: %v", err)
+		}
+		if len(result) != len(chunks) || result[10].Text != "SSN [SSN]" {
+			t.Errorf("Unexpected result: %v", result)
+		}
+		if elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+			t.Errorf("Concurrency %d: processing took %v, expected about 200ms", concurrency, elapsed)
+		}
+	}
+
+	// Zero means unlimited
+	start := time.Now()
+	NewRedactionEngine(DefaultConfig()).Process(chunks)
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Errorf("Unthrottled processing took %v", elapsed)
+	}
+}
@@ -0,0 +1,253 @@
+package piiredact
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// tokenHexLen is the number of hex characters of the HMAC kept in a token.
+// 48 bits keeps tokens short while making collisions unlikely below
+// millions of distinct values per pattern.
+const tokenHexLen = 12
+
+// maxScrubLine bounds how much of a single line the tokenizing reader
+// buffers before it cuts the line at the last whitespace.
+const maxScrubLine = 1 << 20
+
+// tokenKey returns the configured token key or, if none is set, a random
+// key so tokens are stable for the lifetime of the engine only.
+func tokenKey(key []byte) []byte {
+	if len(key) > 0 {
+		return key
+	}
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		panic("piiredact: reading random token key: " + err.Error())
+	}
+	return random
+}
+
+// TokenScope selects the namespace in which tokens are derived.
+type TokenScope int
+
+const (
+	// TokenGlobal gives a value the same token whoever said it, so a phone
+	// number can be followed across both sides of a conversation.
+	TokenGlobal TokenScope = iota
+
+	// TokenPerSpeaker derives tokens per Chunk.Speaker, so the same value
+	// from two speakers gets two tokens. Chunks without a Speaker use the
+	// global namespace.
+	TokenPerSpeaker
+)
+
+// tokenNamespace returns the namespace for the pass's tokens under
+// Config.TokenScope; "" is the global namespace.
+func (e *RedactionEngine) tokenNamespace(r *redaction) string {
+	if e.config.TokenScope == TokenPerSpeaker {
+		return r.speaker
+	}
+	return ""
+}
+
+// token derives the stable token for a value, e.g. "SSN_3f9a0c1d2b7e".
+//
+// It is an HMAC-SHA256 over the pattern name, the namespace if not empty,
+// and the value, so the same value always maps to the same token under the
+// same key and namespace, and tokens cannot be reversed without the
+// sidecar mapping.
+func (e *RedactionEngine) token(name, namespace, value string) string {
+	mac := hmac.New(sha256.New, e.tokenKey)
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	if namespace != "" {
+		mac.Write([]byte(namespace))
+		mac.Write([]byte{0})
+	}
+	mac.Write([]byte(value))
+	return name + "_" + hex.EncodeToString(mac.Sum(nil))[:tokenHexLen]
+}
+
+// TokenMapping is one line of the token sidecar.
+//
+// The sidecar is JSON Lines with one record per distinct token, written the
+// first time that token is produced:
+//
+//	{"token":"[SSN_3f9a0c1d2b7e]","pattern":"SSN","value":"123-45-6789"}
+//
+// Token is the exact text inserted into the output. Value is the raw PII,
+// so the sidecar must be written to storage with stricter access controls
+// than the scrubbed data.
+type TokenMapping struct {
+	Token   string `json:"token"`   // Replacement text as it appears in the output
+	Pattern string `json:"pattern"` // Name of the pattern that matched
+	Value   string `json:"value"`   // Original value
+}
+
+// tokenSidecar writes each distinct token mapping once.
+type tokenSidecar struct {
+	w    io.Writer
+	mu   sync.Mutex
+	seen map[string]bool
+	err  error // First write error
+}
+
+// newTokenSidecar wraps w, returning nil if w is nil.
+func newTokenSidecar(w io.Writer) *tokenSidecar {
+	if w == nil {
+		return nil
+	}
+	return &tokenSidecar{w: w, seen: make(map[string]bool)}
+}
+
+// record writes the mapping for token unless it was already written.
+// It is safe to call on a nil sidecar.
+func (s *tokenSidecar) record(token, pattern, value string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[token] {
+		return
+	}
+	s.seen[token] = true
+
+	line, _ := json.Marshal(TokenMapping{Token: token, Pattern: pattern, Value: value})
+	if _, err := s.w.Write(append(line, '\n')); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// writeError returns the first sidecar write error, if any.
+func (s *tokenSidecar) writeError() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// flush flushes the sidecar's writer if it is buffered. It is safe to call
+// on a nil sidecar.
+func (s *tokenSidecar) flush() error {
+	if s == nil {
+		return nil
+	}
+	f, ok := s.w.(flusher)
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return f.Flush()
+}
+
+// tokenizingReader scrubs a line-oriented stream, replacing PII with tokens.
+type tokenizingReader struct {
+	e       *RedactionEngine
+	src     *bufio.Reader
+	sidecar *tokenSidecar
+	pending []byte // Partial line carried over from the previous read
+	out     []byte // Scrubbed bytes not yet returned to the caller
+	line    int    // Number of lines processed, used to label chunks in logs
+	err     error  // Terminal error from the source or the sidecar
+}
+
+// NewTokenizingReader returns a reader that scrubs r in real time,
+// replacing every detected value with its stable token regardless of the
+// configured Mode. This suits log pipelines such as SIEM ingestion, where
+// events must be correlated by token without storing raw PII.
+//
+// Input is processed a line at a time, so matches never straddle a read
+// boundary; only one line is buffered, and the reader only pulls from r as
+// fast as the caller reads, which provides natural backpressure. A line
+// longer than 1 MiB is cut at its last whitespace and scanned in pieces.
+//
+// If sidecar is non-nil, each distinct token's mapping is written to it as
+// a TokenMapping JSON line. A sidecar write failure stops the stream with
+// that error so no token is emitted without its mapping.
+func (e *RedactionEngine) NewTokenizingReader(r io.Reader, sidecar io.Writer) io.Reader {
+	return &tokenizingReader{
+		e:       e,
+		src:     bufio.NewReader(r),
+		sidecar: newTokenSidecar(sidecar),
+	}
+}
+
+// Read implements io.Reader.
+func (t *tokenizingReader) Read(p []byte) (int, error) {
+	for len(t.out) == 0 && t.err == nil {
+		t.fill()
+	}
+	if len(t.out) > 0 {
+		n := copy(p, t.out)
+		t.out = t.out[n:]
+		return n, nil
+	}
+	return 0, t.err
+}
+
+// fill reads the next line (or over-long line segment) and scrubs it.
+func (t *tokenizingReader) fill() {
+	data, err := t.src.ReadSlice('\n')
+	t.pending = append(t.pending, data...)
+
+	switch {
+	case err == nil:
+		// A complete line
+	case errors.Is(err, bufio.ErrBufferFull):
+		if len(t.pending) < maxScrubLine {
+			return // Keep accumulating the line
+		}
+		// Cut an over-long line after its last whitespace
+		cut := bytes.LastIndexAny(t.pending, " \t\r") + 1
+		if cut == 0 {
+			cut = len(t.pending)
+		}
+		t.scrub(t.pending[:cut])
+		t.pending = append([]byte(nil), t.pending[cut:]...)
+		return
+	default:
+		// EOF or a read error: flush what remains, then stop
+		t.err = err
+	}
+
+	t.scrub(t.pending)
+	t.pending = t.pending[:0]
+}
+
+// scrub tokenizes one segment and queues it for output.
+func (t *tokenizingReader) scrub(segment []byte) {
+	if len(segment) == 0 {
+		return
+	}
+	t.line++
+
+	body, ending := splitLineEnding(string(segment))
+	t.e.configMu.RLock()
+	defer t.e.configMu.RUnlock()
+	c := t.e.redactChunkWith(Chunk{UUID: "line-" + strconv.Itoa(t.line), Text: body}, &redaction{
+		counts:   make(map[string]int),
+		tokenize: true,
+		sidecar:  t.sidecar,
+	})
+	t.out = append(t.out, c.Text...)
+	t.out = append(t.out, ending...)
+
+	if err := t.sidecar.writeError(); err != nil {
+		t.out = t.out[:0]
+		t.err = err
+	}
+}
@@ -0,0 +1,151 @@
+package piiredact
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestRedactionEngine_ModeToken tests that equal values get equal, keyed tokens
+func TestRedactionEngine_ModeToken(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = ModeToken
+	config.TokenKey = []byte("test-key")
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789"},
+		{UUID: "id2", Speaker: "B", Text: "again 123-45-6789, other 401-23-4567"},
+	}
+	result, _ := engine.Process(chunks)
+
+	tokenRe := regexp.MustCompile(`\[SSN_[0-9a-f]{12}\]`)
+	first := tokenRe.FindString(result[0].Text)
+	second := tokenRe.FindAllString(result[1].Text, -1)
+
+	if first == "" || len(second) != 2 {
+		t.Fatalf("Expected SSN tokens, got %q and %q", result[0].Text, result[1].Text)
+	}
+	if second[0] != first {
+		t.Errorf("Same SSN produced different tokens: %s vs %s", first, second[0])
+	}
+	if second[1] == first {
+		t.Errorf("Different SSNs produced the same token %s", first)
+	}
+
+	// A second engine with the same key produces the same tokens
+	other := NewRedactionEngine(config)
+	again, _ := other.Process(chunks[:1])
+	if again[0].Text != result[0].Text {
+		t.Errorf("Tokens are not stable across engines with the same key: %q vs %q",
+			result[0].Text, again[0].Text)
+	}
+}
+
+// TestRedactionEngine_TokenScope tests global and per-speaker token namespaces
+func TestRedactionEngine_TokenScope(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "call 404-555-1212"},
+		{UUID: "id2", Speaker: "B", Text: "call 404-555-1212"},
+		{UUID: "id3", Speaker: "A", Text: "yes 404-555-1212"},
+		{UUID: "id4", Text: "call 404-555-1212"},
+	}
+	tokenRe := regexp.MustCompile(`\[PHONE_[0-9a-f]{12}\]`)
+
+	tokens := func(scope TokenScope) []string {
+		config := DefaultConfig()
+		config.Mode = ModeToken
+		config.TokenKey = []byte("test-key")
+		config.TokenScope = scope
+		result, _ := NewRedactionEngine(config).Process(chunks)
+
+		found := make([]string, len(result))
+		for i, c := range result {
+			if found[i] = tokenRe.FindString(c.Text); found[i] == "" {
+				t.Fatalf("Scope %d: no token in %q", scope, c.Text)
+			}
+		}
+		return found
+	}
+
+	global := tokens(TokenGlobal)
+	for i, token := range global {
+		if token != global[0] {
+			t.Errorf("Global scope: chunk %d got %s, expected %s", i, token, global[0])
+		}
+	}
+
+	perSpeaker := tokens(TokenPerSpeaker)
+	if perSpeaker[0] == perSpeaker[1] {
+		t.Errorf("Per-speaker scope: speakers A and B share token %s", perSpeaker[0])
+	}
+	if perSpeaker[0] != perSpeaker[2] {
+		t.Errorf("Per-speaker scope: speaker A got %s and %s", perSpeaker[0], perSpeaker[2])
+	}
+	if perSpeaker[0] == global[0] {
+		t.Errorf("Per-speaker scope: speaker A reused the global token %s", global[0])
+	}
+	// A chunk without a speaker falls back to the global namespace
+	if perSpeaker[3] != global[0] {
+		t.Errorf("Per-speaker scope: speakerless chunk got %s, expected %s", perSpeaker[3], global[0])
+	}
+}
+
+// TestTokenizingReader tests scrubbing a log stream with a token sidecar
+func TestTokenizingReader(t *testing.T) {
+	logs := "2025-01-02 login user=jane@example.com ip=10.0.0.1\n" +
+		"2025-01-02 reset user=jane@example.com ssn=123-45-6789\r\n" +
+		"2025-01-02 logout no pii here"
+
+	config := DefaultConfig()
+	config.TokenKey = []byte("test-key")
+	engine := NewRedactionEngine(config)
+
+	var sidecar bytes.Buffer
+	// Feed one byte at a time so every match straddles a read boundary
+	reader := engine.NewTokenizingReader(iotest.OneByteReader(strings.NewReader(logs)), &sidecar)
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll returned error: %v", err)
+	}
+
+	lines := strings.Split(string(out), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[1], "\r") || lines[2] != "2025-01-02 logout no pii here" {
+		t.Fatalf("Line structure not preserved: %q", out)
+	}
+	for _, raw := range []string{"jane@example.com", "10.0.0.1", "123-45-6789"} {
+		if strings.Contains(string(out), raw) {
+			t.Errorf("Output leaked %q: %s", raw, out)
+		}
+	}
+
+	emailToken := regexp.MustCompile(`\[EMAIL_[0-9a-f]{12}\]`).FindAllString(string(out), -1)
+	if len(emailToken) != 2 || emailToken[0] != emailToken[1] {
+		t.Errorf("Expected the same EMAIL token twice, got %v", emailToken)
+	}
+
+	// One mapping per distinct token: EMAIL, IP and SSN
+	mappings := make(map[string]TokenMapping)
+	scanner := bufio.NewScanner(&sidecar)
+	for scanner.Scan() {
+		var m TokenMapping
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("Invalid sidecar line %q: %v", scanner.Text(), err)
+		}
+		if _, dup := mappings[m.Token]; dup {
+			t.Errorf("Token %s written to the sidecar twice", m.Token)
+		}
+		mappings[m.Token] = m
+	}
+	if len(mappings) != 3 {
+		t.Fatalf("Expected 3 sidecar mappings, got %d", len(mappings))
+	}
+	if m := mappings[emailToken[0]]; m.Pattern != "EMAIL" || m.Value != "jane@example.com" {
+		t.Errorf("Unexpected EMAIL mapping: %+v", m)
+	}
+}
@@ -0,0 +1,144 @@
+package piiredact
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// urlRegex matches http and https URLs up to the next whitespace or quote.
+var urlRegex = regexp.MustCompile(`\bhttps?://[^\s<>"'` + "`" + `]+`)
+
+// urlPriority lets a rewritten URL win over any pattern matching inside it.
+const urlPriority = 1 << 20
+
+// urlPattern returns the pattern behind Config.URLAware.
+//
+// It matches URLs whose query string contains PII and rewrites them with
+// redactURL, so labels are percent-encoded into the parameter values and the
+// URL stays parseable. URLs without a query, or whose query is clean, are
+// left to the ordinary patterns.
+//
+// Matching only probes the URL's parts with hasPII; the real redaction,
+// including OnMatch, detectors, language filtering and known values, runs
+// once in rewrite, and a URL it leaves unchanged is dropped by redactText.
+func (e *RedactionEngine) urlPattern() PatternDef {
+	return PatternDef{
+		Name:        "URL",
+		Description: "URL with PII in its query",
+		Regex:       urlRegex,
+		Priority:    urlPriority,
+		find: func(text string) [][]int {
+			var spans [][]int
+			for _, m := range urlRegex.FindAllStringIndex(text, -1) {
+				base, query, hasQuery, fragment := splitURL(text[m[0]:m[1]])
+				if !hasQuery {
+					continue
+				}
+				found := e.hasPII(base) || e.hasPII(fragment)
+				for _, param := range strings.Split(query, "&") {
+					if found {
+						break
+					}
+					if _, value, ok := strings.Cut(param, "="); ok {
+						found = e.hasPII(queryUnescape(value))
+					}
+				}
+				if found {
+					spans = append(spans, m)
+				}
+			}
+			return spans
+		},
+		rewrite: e.redactURL,
+	}
+}
+
+// hasPII reports whether any regex pattern accepts a match in text. It is a
+// cheap probe: detectors, rewriting patterns and OnMatch are not run, and
+// nothing is redacted or counted.
+func (e *RedactionEngine) hasPII(text string) bool {
+	if text == "" {
+		return false
+	}
+	present := e.requires.present(text)
+	for i, p := range e.patterns {
+		if p.detector != nil || p.rewrite != nil || !e.requires.met(i, text, present) {
+			continue
+		}
+		for _, s := range p.findAll(text) {
+			if e.accepts(text, match{start: s[0], end: s[1], pattern: i}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitURL splits raw into the part before the query, the query without its
+// "?", whether there was a query, and the fragment including its "#".
+func splitURL(raw string) (base, query string, hasQuery bool, fragment string) {
+	base = raw
+	if i := strings.IndexByte(base, '#'); i >= 0 {
+		base, fragment = base[:i], base[i:]
+	}
+	if i := strings.IndexByte(base, '?'); i >= 0 {
+		base, query, hasQuery = base[:i], base[i+1:], true
+	}
+	return base, query, hasQuery, fragment
+}
+
+// queryUnescape percent-decodes a query value. Malformed escapes are
+// returned as-is so they are still scanned.
+func queryUnescape(value string) string {
+	decoded, err := url.QueryUnescape(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// redactURL redacts PII inside a URL while keeping its structure.
+//
+// Query parameters keep their order and keys; each value is percent-decoded,
+// redacted and, only if something changed, re-encoded with url.QueryEscape.
+// The scheme, host, path and fragment are redacted as plain text. Inner
+// redactions are added to r.counts under their own pattern names.
+func (e *RedactionEngine) redactURL(raw string, r *redaction) string {
+	base, query, hasQuery, fragment := splitURL(raw)
+
+	var b strings.Builder
+	redactedBase, _ := e.redactText(base, r)
+	b.WriteString(redactedBase)
+
+	if hasQuery {
+		b.WriteByte('?')
+		for i, param := range strings.Split(query, "&") {
+			if i > 0 {
+				b.WriteByte('&')
+			}
+
+			key, value, hasValue := strings.Cut(param, "=")
+			b.WriteString(key)
+			if !hasValue {
+				continue
+			}
+			b.WriteByte('=')
+
+			decoded := queryUnescape(value)
+			redacted, _ := e.redactText(decoded, r)
+			if redacted == decoded {
+				b.WriteString(value)
+			} else {
+				b.WriteString(url.QueryEscape(redacted))
+			}
+		}
+	}
+
+	if fragment != "" {
+		redactedFragment, _ := e.redactText(fragment, r)
+		b.WriteString(redactedFragment)
+	}
+
+	return b.String()
+}
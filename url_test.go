@@ -0,0 +1,120 @@
+package piiredact
+
+import (
+	"net/url"
+	"testing"
+)
+
+// TestRedactionEngine_URLAware tests redaction of PII inside URL query parameters
+func TestRedactionEngine_URLAware(t *testing.T) {
+	config := DefaultConfig()
+	config.URLAware = true
+	engine := NewRedactionEngine(config)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"see https://site.com/?email=a@b.com&ssn=123456789 now",
+			"see https://site.com/?email=%5BEMAIL%5D&ssn=%5BSSN%5D now",
+		},
+		{
+			"https://site.com/p?ref=abc&contact=jane%40example.com#top",
+			"https://site.com/p?ref=abc&contact=%5BEMAIL%5D#top",
+		},
+		{
+			// No PII in the query: ordinary patterns still cover the rest of the text
+			"https://site.com/?page=2 or mail user@example.com",
+			"https://site.com/?page=2 or mail [EMAIL]",
+		},
+	}
+
+	for _, tt := range tests {
+		result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: tt.input}})
+		if result[0].Text != tt.expected {
+			t.Errorf("URL redaction failed for %q:\nExpected: %s\nGot: %s",
+				tt.input, tt.expected, result[0].Text)
+		}
+	}
+
+	// The rewritten URL must still parse with intact parameters
+	result, _ := engine.Process([]Chunk{{UUID: "id1", Speaker: "A", Text: "https://site.com/?email=a@b.com&ssn=123456789"}})
+	u, err := url.Parse(result[0].Text)
+	if err != nil {
+		t.Fatalf("Redacted URL does not parse: %v", err)
+	}
+	if got := u.Query().Get("email"); got != "[EMAIL]" {
+		t.Errorf("Expected email=[EMAIL], got %q", got)
+	}
+	if got := u.Query().Get("ssn"); got != "[SSN]" {
+		t.Errorf("Expected ssn=[SSN], got %q", got)
+	}
+
+	metrics := engine.GetMetrics()
+	if metrics.RedactedItems["EMAIL"] < 1 || metrics.RedactedItems["SSN"] < 1 {
+		t.Errorf("Expected inner redactions to be counted, got %v", metrics.RedactedItems)
+	}
+}
+
+// TestRedactionEngine_URLAwareState tests that URL parameters see the same
+// OnMatch, language filtering and known values as the rest of the chunk
+func TestRedactionEngine_URLAwareState(t *testing.T) {
+	const link = "https://site.com/?email=a@b.com&ssn=401-23-4567"
+
+	// OnMatch runs once per value inside the URL; calls on the whole text
+	// come from the ordinary scan of the chunk
+	var calls []string
+	config := DefaultConfig()
+	config.URLAware = true
+	config.OnMatch = func(pattern, value string, start, end int, text string) bool {
+		if text != link {
+			calls = append(calls, pattern)
+		}
+		return pattern != "SSN"
+	}
+	engine := NewRedactionEngine(config)
+	result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: link}})
+	if want := "https://site.com/?email=%5BEMAIL%5D&ssn=401-23-4567"; result[0].Text != want {
+		t.Errorf("OnMatch veto:\nExpected: %s\nGot: %s", want, result[0].Text)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Expected OnMatch to be called twice, got %v", calls)
+	}
+
+	// Patterns excluded for the chunk's language are excluded in URLs too
+	config = DefaultConfig()
+	config.URLAware = true
+	config.LanguagePatterns = map[string][]string{"es": {"URL", "EMAIL"}}
+	engine = NewRedactionEngine(config)
+	langCases := []struct {
+		text     string
+		expected string
+	}{
+		{link, "https://site.com/?email=%5BEMAIL%5D&ssn=401-23-4567"},
+		{"https://site.com/?ssn=401-23-4567", "https://site.com/?ssn=401-23-4567"},
+	}
+	for _, tc := range langCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.text, Lang: "es"}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Lang es, %q:\nExpected: %s\nGot: %s", tc.text, tc.expected, result[0].Text)
+		}
+	}
+
+	// Values RedactNew already saw in the baseline stay as they are
+	config = DefaultConfig()
+	config.URLAware = true
+	engine = NewRedactionEngine(config)
+	knownCases := []struct {
+		text     string
+		expected string
+	}{
+		{"see " + link, "see https://site.com/?email=a@b.com&ssn=%5BSSN%5D"},
+		{"see https://site.com/?email=a@b.com", "see https://site.com/?email=a@b.com"},
+	}
+	for _, tc := range knownCases {
+		if got := engine.RedactNew("mail a@b.com", tc.text); got != tc.expected {
+			t.Errorf("RedactNew(%q):\nExpected: %s\nGot: %s", tc.text, tc.expected, got)
+		}
+	}
+}
@@ -1,20 +1,63 @@
 package piiredact
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
+// ErrUnknownPattern is returned by ValidateValue for names that are not
+// builtin patterns.
+var ErrUnknownPattern = errors.New("piiredact: unknown pattern")
+
+// ErrNoValidator is returned by ValidateValue for builtin patterns that have
+// no validation function.
+var ErrNoValidator = errors.New("piiredact: pattern has no validator")
+
+// ValidateValue reports whether value is a valid instance of the named
+// builtin pattern, such as "SSN", "CC" or "ABA", without redacting anything.
+//
+// The whole value must match the pattern's format (surrounding whitespace
+// is not trimmed) and pass its validator, so "123-45-6789" is a valid SSN
+// but "SSN 123-45-6789" is not. It returns ErrUnknownPattern or
+// ErrNoValidator, wrapped with the name, if the pattern cannot be checked.
+func ValidateValue(patternName, value string) (bool, error) {
+	for _, p := range slices.Concat(builtinPatterns, optionalPatterns) {
+		if p.Name != patternName {
+			continue
+		}
+		if p.Validate == nil {
+			return false, fmt.Errorf("%w: %s", ErrNoValidator, patternName)
+		}
+		if loc := p.Regex.FindStringIndex(value); loc == nil || loc[0] != 0 || loc[1] != len(value) {
+			return false, nil
+		}
+		return p.Validate(value), nil
+	}
+	return false, fmt.Errorf("%w: %s", ErrUnknownPattern, patternName)
+}
+
+// ssnSeparators removes the separators the SSN and ITIN patterns accept.
+var ssnSeparators = strings.NewReplacer("-", "", ".", "", " ", "")
+
 // validateSSN checks if a potential SSN follows valid format rules.
 //
 // It applies various validation rules to minimize false positives:
 // - Rejects all-same-digit patterns (e.g., 111-11-1111)
 // - Validates against SSA issuance rules (no 000, 666, 900+ area numbers)
 // - Checks for valid group and serial numbers
+//
+// Hyphens, dots and spaces between the digit groups are ignored.
 func validateSSN(ssn string) bool {
-	// Remove hyphens for validation
-	cleaned := strings.ReplaceAll(ssn, "-", "")
+	// Remove separators for validation
+	cleaned := ssnSeparators.Replace(ssn)
+	if len(cleaned) != 9 {
+		return false
+	}
 
 	// Check for obviously invalid patterns (all same digit)
 	if cleaned == "000000000" || cleaned == "111111111" ||
@@ -46,6 +89,36 @@ func validateSSN(ssn string) bool {
 	return true
 }
 
+// validateITIN checks if a potential ITIN follows IRS issuance rules.
+//
+// ITINs share the SSN layout but always start with 9, which validateSSN
+// rejects, and the middle two digits are restricted to the ranges the IRS
+// assigns: 50-65, 70-88, 90-92 and 94-99. Separators are ignored as in
+// validateSSN.
+func validateITIN(itin string) bool {
+	// Remove separators for validation
+	cleaned := ssnSeparators.Replace(itin)
+	if len(cleaned) != 9 || cleaned[0] != '9' {
+		return false
+	}
+
+	middle2, err := strconv.Atoi(cleaned[3:5])
+	if err != nil {
+		return false
+	}
+	switch {
+	case middle2 >= 50 && middle2 <= 65:
+	case middle2 >= 70 && middle2 <= 88:
+	case middle2 >= 90 && middle2 <= 92:
+	case middle2 >= 94 && middle2 <= 99:
+	default:
+		return false
+	}
+
+	// Last 4 digits can't be 0000
+	return cleaned[5:] != "0000"
+}
+
 // validateLuhn implements the Luhn algorithm for credit card validation.
 //
 // This algorithm detects accidental errors in identification numbers:
@@ -86,6 +159,139 @@ func validateLuhn(number string) bool {
 	return sum%10 == 0
 }
 
+// luhnExempt returns a card validator that accepts numbers starting with
+// one of prefixes without a Luhn check, for sandbox test cards and issuer
+// ranges that do not use a check digit. Other numbers must pass
+// validateLuhn. Separators in the prefixes and the number are ignored.
+func luhnExempt(prefixes []string) func(string) bool {
+	strip := strings.NewReplacer(" ", "", "-", "")
+	var cleaned []string
+	for _, prefix := range prefixes {
+		if prefix = strip.Replace(prefix); prefix != "" {
+			cleaned = append(cleaned, prefix)
+		}
+	}
+
+	return func(number string) bool {
+		digits := strip.Replace(number)
+		for _, prefix := range cleaned {
+			if strings.HasPrefix(digits, prefix) {
+				return true
+			}
+		}
+		return validateLuhn(number)
+	}
+}
+
+// validateIMEI checks that an IMEI has 15 digits and a valid Luhn check digit.
+func validateIMEI(imei string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(imei)
+	return len(digits) == 15 && validateLuhn(digits)
+}
+
+// validateNANP checks that a phone number is a plausible North American
+// number: ten digits after an optional leading 1, with an area code and
+// exchange that do not start with 0 or 1, and an area code that is not an
+// N11 service code such as 911. Any extension is ignored.
+func validateNANP(phone string) bool {
+	// Drop the extension, which starts at the first letter ("ext", "x")
+	if i := strings.IndexFunc(phone, unicode.IsLetter); i >= 0 {
+		phone = phone[:i]
+	}
+
+	var digits []byte
+	for i := 0; i < len(phone); i++ {
+		if phone[i] >= '0' && phone[i] <= '9' {
+			digits = append(digits, phone[i])
+		}
+	}
+	if len(digits) == 11 && digits[0] == '1' {
+		digits = digits[1:]
+	}
+	if len(digits) != 10 {
+		return false
+	}
+
+	area, exchange := digits[0:3], digits[3:6]
+	return area[0] >= '2' && exchange[0] >= '2' && !(area[1] == '1' && area[2] == '1')
+}
+
+// mbiLetters are the letters allowed in a Medicare Beneficiary Identifier;
+// S, L, O, I, B and Z are never used because they are easily confused
+// with digits.
+const mbiLetters = "ACDEFGHJKMNPQRTUVWXY"
+
+// validateMBI checks a Medicare Beneficiary Identifier against the CMS
+// position rules. Its 11 characters are, in order: a digit 1-9; a letter;
+// a letter or digit; a digit; a letter; a letter or digit; a digit; two
+// letters; and two digits. Letters must come from mbiLetters, and dashes
+// are allowed only as the pair in the printed form "1EG4-TE5-MK73".
+func validateMBI(mbi string) bool {
+	mbi = strings.ToUpper(mbi)
+	if len(mbi) == 13 {
+		if mbi[4] != '-' || mbi[8] != '-' {
+			return false
+		}
+		mbi = mbi[:4] + mbi[5:8] + mbi[9:]
+	}
+	if len(mbi) != 11 {
+		return false
+	}
+
+	const layout = "CAXNAXNAANN" // C: digit 1-9, A: letter, N: digit, X: letter or digit
+	for i := 0; i < len(mbi); i++ {
+		c := mbi[i]
+		isDigit := c >= '0' && c <= '9'
+		isLetter := strings.IndexByte(mbiLetters, c) >= 0
+		switch layout[i] {
+		case 'C':
+			if !isDigit || c == '0' {
+				return false
+			}
+		case 'A':
+			if !isLetter {
+				return false
+			}
+		case 'N':
+			if !isDigit {
+				return false
+			}
+		case 'X':
+			if !isDigit && !isLetter {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateNHS checks a UK NHS number's mod-11 check digit. Each of the
+// first nine digits is weighted 10 down to 2; the check digit is 11 minus
+// the weighted sum mod 11, with 11 written as 0. A result of 10 cannot be
+// written as a digit, so numbers that would need it are never issued.
+func validateNHS(nhs string) bool {
+	nhs = strings.ReplaceAll(nhs, " ", "")
+	if len(nhs) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 10; i++ {
+		if nhs[i] < '0' || nhs[i] > '9' {
+			return false
+		}
+		if i < 9 {
+			sum += int(nhs[i]-'0') * (10 - i)
+		}
+	}
+
+	check := 11 - sum%11
+	if check == 11 {
+		check = 0
+	}
+	return check != 10 && check == int(nhs[9]-'0')
+}
+
 // validateABA checks if a routing number is valid using the checksum algorithm.
 //
 // ABA routing numbers use a specific checksum algorithm:
@@ -96,8 +302,12 @@ func validateABA(aba string) bool {
 		return false
 	}
 
-	// First digit can't be 0
-	if aba[0] == '0' {
+	// The first two digits must be a Federal Reserve routing symbol (01-12),
+	// a thrift institution prefix (21-32), an electronic prefix (61-72) or
+	// the traveler's cheque prefix (80); 00 is never issued, which also
+	// rules out "000000000"
+	prefix := int(aba[0]-'0')*10 + int(aba[1]-'0')
+	if !((prefix >= 1 && prefix <= 12) || (prefix >= 21 && prefix <= 32) || (prefix >= 61 && prefix <= 72) || prefix == 80) {
 		return false
 	}
 
@@ -0,0 +1,143 @@
+package piiredact
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidateITIN tests the IRS ITIN issuance rules
+func TestValidateITIN(t *testing.T) {
+	testCases := []struct {
+		itin  string
+		valid bool
+	}{
+		{"912-50-1234", true},
+		{"912-65-1234", true},
+		{"999-70-1234", true},
+		{"900-88-1234", true},
+		{"912-90-1234", true},
+		{"912-92-1234", true},
+		{"912-94-1234", true},
+		{"912-99-1234", true},
+		{"912701234", true},
+		{"912.70.1234", true},
+		{"912 70 1234", true},
+		{"812-70-1234", false}, // Does not start with 9
+		{"912-49-1234", false}, // Middle digits below the ITIN ranges
+		{"912-66-1234", false},
+		{"912-89-1234", false},
+		{"912-93-1234", false},
+		{"912-70-0000", false}, // Zero serial number
+		{"912-70-123", false},
+	}
+
+	for _, tc := range testCases {
+		if got := validateITIN(tc.itin); got != tc.valid {
+			t.Errorf("validateITIN(%q) = %v, expected %v", tc.itin, got, tc.valid)
+		}
+	}
+}
+
+// TestRedactionEngine_ITIN tests that ITINs get their own label
+func TestRedactionEngine_ITIN(t *testing.T) {
+	engine := NewRedactionEngine(DefaultConfig())
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"My ITIN is 912-70-1234", "My ITIN is [ITIN]"},
+		{"ITIN 900501234 on file", "ITIN [ITIN] on file"},
+		{"ITIN 912 70 1234 on file", "ITIN [ITIN] on file"},
+		{"ITIN 912.70.1234.", "ITIN [ITIN]."},
+		{"ITIN 912-70 1234", "ITIN 912-70 1234"}, // Mixed separators
+
+		{"Not an ITIN: 912-40-1234", "Not an ITIN: 912-40-1234"},
+		{"SSN 401-23-4567", "SSN [SSN]"},
+	}
+
+	for _, tc := range testCases {
+		result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: tc.input}})
+		if result[0].Text != tc.expected {
+			t.Errorf("Input: %s\nExpected: %s\nGot: %s", tc.input, tc.expected, result[0].Text)
+		}
+	}
+}
+
+// TestRedactionEngine_LuhnExemptPrefixes tests redacting listed cards that fail Luhn
+func TestRedactionEngine_LuhnExemptPrefixes(t *testing.T) {
+	inputs := []string{
+		"Sandbox card 4000 1234 5678 9012",
+		"Other card 5500-1234-5678-9012",
+		"Real card 4111111111111111",
+	}
+
+	testCases := []struct {
+		prefixes []string
+		expected []string
+	}{
+		{nil, []string{
+			"Sandbox card 4000 1234 5678 9012",
+			"Other card 5500-1234-5678-9012",
+			"Real card [CC]",
+		}},
+		{[]string{"4000 12", "999"}, []string{
+			"Sandbox card [CC]",
+			"Other card 5500-1234-5678-9012",
+			"Real card [CC]",
+		}},
+	}
+
+	for _, tc := range testCases {
+		config := DefaultConfig()
+		config.LuhnExemptPrefixes = tc.prefixes
+		engine := NewRedactionEngine(config)
+
+		for i, input := range inputs {
+			result, _ := engine.Process([]Chunk{{UUID: "u", Speaker: "A", Text: input}})
+			if result[0].Text != tc.expected[i] {
+				t.Errorf("Prefixes %v: Input: %s\nExpected: %s\nGot: %s", tc.prefixes, input, tc.expected[i], result[0].Text)
+			}
+		}
+	}
+}
+
+// TestValidateValue tests validating single values by pattern name
+func TestValidateValue(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		value   string
+		valid   bool
+		err     error
+	}{
+		{"SSN", "401-23-4567", true, nil},
+		{"SSN", "666-23-4567", false, nil},
+		{"SSN", "12", false, nil},
+		{"SSN", "SSN 401-23-4567", false, nil},
+		{"SSN", "401.23.4567", true, nil},
+		{"SSN", "401 23 4567", true, nil},
+		{"SSN", "666.23.4567", false, nil},
+		{"SSN", "401-23-45678", false, nil},
+		{"CC", "4111 1111 1111 1111", true, nil},
+		{"CC", "4111 1111 1111 1112", false, nil},
+		{"ABA", "111000025", true, nil},
+		{"ABA", "111000026", false, nil},
+		{"ABA", "021000021", true, nil},
+		{"ABA", "501000028", false, nil}, // Unassigned prefix
+		{"ABA", "000000000", false, nil}, // All zeros
+		{"ABA", "001000025", false, nil}, // Prefix 00 passes the checksum but is never issued
+		{"ITIN", "912-70-1234", true, nil},
+		{"EMAIL", "jane@example.com", false, ErrNoValidator},
+		{"NOPE", "anything", false, ErrUnknownPattern},
+	}
+
+	for _, tc := range testCases {
+		valid, err := ValidateValue(tc.pattern, tc.value)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("ValidateValue(%q, %q) error = %v, expected %v", tc.pattern, tc.value, err, tc.err)
+		}
+		if valid != tc.valid {
+			t.Errorf("ValidateValue(%q, %q) = %v, expected %v", tc.pattern, tc.value, valid, tc.valid)
+		}
+	}
+}
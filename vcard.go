@@ -0,0 +1,186 @@
+package piiredact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// VCardOptions controls RedactVCard.
+type VCardOptions struct {
+	Properties []string // Properties whose whole value becomes a label, e.g. "ADR" gives "[ADR]"
+}
+
+// vcardVerbatim are properties copied without redaction: card structure,
+// and binary data that patterns would only misread.
+var vcardVerbatim = map[string]bool{
+	"BEGIN": true, "END": true, "VERSION": true, "PRODID": true, "REV": true,
+	"PHOTO": true, "LOGO": true, "SOUND": true, "KEY": true,
+}
+
+// vcardFoldWidth is the line length, in bytes, at which rewritten vCard
+// lines are folded.
+const vcardFoldWidth = 75
+
+// RedactVCard redacts the values of a vCard (.vcf) contact file, keeping
+// its structure.
+//
+// Each property line, such as "TEL;TYPE=cell:+1 404 555 1212", is split
+// into its name and parameters, which are kept, and its value, which is
+// redacted like a chunk of text. Names and addresses rarely match a
+// pattern, so properties listed in opts.Properties (for example "FN", "N"
+// and "ADR") have their whole value replaced by a label made from the
+// property name with RedactionFormat. Property names match without regard
+// to case or group prefix ("item1.TEL" is TEL).
+//
+// BEGIN, END, VERSION and binary properties like PHOTO are never changed,
+// and lines that are not valid property lines are copied through as they
+// are. Folded lines are unfolded before redaction; lines that are left
+// unchanged keep their original folding and line endings, while rewritten
+// lines are folded at 75 bytes.
+func (e *RedactionEngine) RedactVCard(r io.Reader, w io.Writer, opts VCardOptions) error {
+	whole := make(map[string]bool, len(opts.Properties))
+	for _, name := range opts.Properties {
+		whole[strings.ToUpper(name)] = true
+	}
+
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	card := 0            // Number of cards seen, used to label chunks in logs
+	var logical []string // Physical lines, with endings, of the current logical line
+
+	flush := func() error {
+		if len(logical) == 0 {
+			return nil
+		}
+		defer func() { logical = logical[:0] }()
+
+		e.configMu.RLock()
+		out, changed := e.redactVCardLine(logical, whole, &card)
+		e.configMu.RUnlock()
+		if !changed {
+			out = strings.Join(logical, "")
+		}
+		_, err := bw.WriteString(out)
+		return err
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			// A line starting with a space or tab continues the previous one
+			if line[0] != ' ' && line[0] != '\t' {
+				if werr := flush(); werr != nil {
+					return werr
+				}
+			}
+			logical = append(logical, line)
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// redactVCardLine redacts one logical vCard line, given as its physical
+// lines, and reports whether the value changed. card counts BEGIN:VCARD
+// lines seen so far.
+func (e *RedactionEngine) redactVCardLine(physical []string, whole map[string]bool, card *int) (string, bool) {
+	// Unfold, dropping each continuation's leading space or tab
+	var unfolded strings.Builder
+	for i, line := range physical {
+		body, _ := splitLineEnding(line)
+		if i > 0 {
+			body = body[1:]
+		}
+		unfolded.WriteString(body)
+	}
+	_, ending := splitLineEnding(physical[0])
+
+	head, value, ok := splitVCardProperty(unfolded.String())
+	if !ok {
+		return "", false
+	}
+	name := strings.ToUpper(head)
+	if i := strings.IndexByte(name, ';'); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:] // Drop the group prefix
+	}
+
+	if name == "BEGIN" && strings.EqualFold(strings.TrimSpace(value), "VCARD") {
+		*card++
+	}
+
+	var redacted string
+	switch {
+	case vcardVerbatim[name] || value == "":
+		return "", false
+	case whole[name]:
+		redacted = fmt.Sprintf(e.config.RedactionFormat, name)
+	default:
+		redacted = e.redactChunk(Chunk{UUID: fmt.Sprintf("vcard-%d", *card), Text: value}).Text
+	}
+	if redacted == value {
+		return "", false
+	}
+	return foldVCardLine(head+":"+redacted, ending), true
+}
+
+// splitVCardProperty splits a property line into the part before the value
+// (group, name and parameters) and the value. The separating colon is the
+// first one outside a quoted parameter value.
+func splitVCardProperty(line string) (head, value string, ok bool) {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if quoted {
+				continue
+			}
+			if i == 0 || line[0] == ';' || line[0] == '.' {
+				return "", "", false
+			}
+			return line[:i], line[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// foldVCardLine folds line so no physical line is longer than
+// vcardFoldWidth bytes, never splitting a UTF-8 sequence, and ends every
+// physical line with ending.
+func foldVCardLine(line, ending string) string {
+	if ending == "" || len(line) <= vcardFoldWidth {
+		return line + ending
+	}
+
+	var b strings.Builder
+	width := vcardFoldWidth
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut-- // Back up to the start of a UTF-8 sequence
+		}
+		b.WriteString(line[:cut])
+		b.WriteString(ending)
+		b.WriteString(" ")
+		line = line[cut:]
+		width = vcardFoldWidth - 1 // Room for the leading space
+	}
+	b.WriteString(line)
+	b.WriteString(ending)
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package piiredact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRedactVCard tests redacting values in a multi-entry vCard file
+func TestRedactVCard(t *testing.T) {
+	input := "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Jane Doe\r\n" +
+		"N:Doe;Jane;;;\r\n" +
+		"TEL;TYPE=\"cell,voice\":+1 404-555-1212\r\n" +
+		"EMAIL;TYPE=work:jane@example.com\r\n" +
+		"NOTE:SSN on file is 401-23-4567, ask before \r\n" +
+		" sharing\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:John Smith\r\n" +
+		"item1.ADR;TYPE=home:;;123 Main St;Springfield;IL;62704;USA\r\n" +
+		"item1.X-ABLabel:Home\r\n" +
+		"TEL:555-123-4567\r\n" +
+		"PHOTO;ENCODING=b;TYPE=JPEG:MIICajCCAdOgAwIBAgICBEUwDQYJ401234567\r\n" +
+		"this line is malformed 555-123-4567\r\n" +
+		"END:VCARD\r\n"
+
+	expected := "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:[FN]\r\n" +
+		"N:[N]\r\n" +
+		"TEL;TYPE=\"cell,voice\":[PHONE]\r\n" +
+		"EMAIL;TYPE=work:[EMAIL]\r\n" +
+		"NOTE:SSN on file is [SSN], ask before sharing\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:[FN]\r\n" +
+		"item1.ADR;TYPE=home:[ADR]\r\n" +
+		"item1.X-ABLabel:Home\r\n" +
+		"TEL:[PHONE]\r\n" +
+		"PHOTO;ENCODING=b;TYPE=JPEG:MIICajCCAdOgAwIBAgICBEUwDQYJ401234567\r\n" +
+		"this line is malformed 555-123-4567\r\n" +
+		"END:VCARD\r\n"
+
+	engine := NewRedactionEngine(DefaultConfig())
+	var out bytes.Buffer
+	opts := VCardOptions{Properties: []string{"FN", "n", "ADR"}}
+	if err := engine.RedactVCard(strings.NewReader(input), &out, opts); err != nil {
+		t.Fatalf("RedactVCard returned error: %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("vCard redaction failed:\nExpected: %q\nGot: %q", expected, out.String())
+	}
+}
+
+// TestFoldVCardLine tests folding long rewritten lines
+func TestFoldVCardLine(t *testing.T) {
+	line := "NOTE:" + strings.Repeat("é", 40)
+	folded := foldVCardLine(line, "\n")
+
+	var unfolded strings.Builder
+	for i, physical := range strings.Split(strings.TrimSuffix(folded, "\n"), "\n") {
+		if len(physical) > vcardFoldWidth {
+			t.Errorf("Line %d is %d bytes long", i, len(physical))
+		}
+		if i > 0 {
+			physical = strings.TrimPrefix(physical, " ")
+		}
+		unfolded.WriteString(physical)
+	}
+	if unfolded.String() != line {
+		t.Errorf("Unfolded %q, expected %q", unfolded.String(), line)
+	}
+	if got := foldVCardLine("TEL:[PHONE]", "\r\n"); got != "TEL:[PHONE]\r\n" {
+		t.Errorf("Short line folded: %q", got)
+	}
+}
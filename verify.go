@@ -0,0 +1,57 @@
+package piiredact
+
+// VerifyRedacted runs a second detection pass over chunks that have
+// already been redacted, typically the output of Process, and returns the
+// chunks in which it still finds PII, in input order. An empty result
+// means the check passed; anything else points to a bug or a pattern gap,
+// and suits compliance-critical runs as a final assertion.
+//
+// Only validated PII counts: matches of patterns with a Validate function,
+// such as SSN or CC, and values reported by detectors. Patterns without
+// one can match labels and masks themselves, as USERNAME matches
+// "/home/[USERNAME]". Values the engine writes on purpose are also
+// ignored: encrypted SSNs in ModeFPE, shifted dates with DateShift and
+// generalized ages. Use an engine with the same Config as the one that
+// redacted the chunks, or a stricter one. Like Detect, VerifyRedacted does
+// not update metrics or write audit records.
+//
+// The check fails closed: a chunk on which a Detector returns an error,
+// such as ErrDetectorUnavailable while an NER service is down, could not
+// be verified and is returned as if it leaked.
+func (e *RedactionEngine) VerifyRedacted(chunks []Chunk) []Chunk {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	var leaks []Chunk
+	for _, c := range chunks {
+		if isEmptyChunk(c) {
+			continue
+		}
+		matches, err := e.resolvedMatches(c.Text, e.langSkip[c.Lang])
+		if err != nil {
+			leaks = append(leaks, c)
+			continue
+		}
+		for _, m := range matches {
+			if e.leaked(m) {
+				leaks = append(leaks, c)
+				break
+			}
+		}
+	}
+	return leaks
+}
+
+// leaked reports whether a match found in redacted text is validated PII
+// rather than a value the engine produced.
+func (e *RedactionEngine) leaked(m match) bool {
+	p := e.patterns[m.pattern]
+	switch name := e.matchName(m); {
+	case p.rewrite != nil:
+		return false
+	case name == "SSN" && e.fpe != nil:
+		return false
+	case name == "DOB" && e.dateShift != 0:
+		return false
+	}
+	return p.Validate != nil || p.detector != nil
+}
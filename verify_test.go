@@ -0,0 +1,72 @@
+package piiredact
+
+import (
+	"testing"
+)
+
+// TestRedactionEngine_VerifyRedacted tests flagging PII left behind by a first pass
+func TestRedactionEngine_VerifyRedacted(t *testing.T) {
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN 123-45-6789, email jane@example.com"},
+		{UUID: "id2", Speaker: "B", Text: "card 4111 1111 1111 1111 from /home/jsmith/"},
+		{UUID: "id3", Speaker: "A", Text: "nothing here"},
+		{UUID: "id4", Speaker: "A", Text: ""},
+	}
+
+	// Redaction with every default pattern leaves nothing behind, whatever the mode
+	configs := map[string]func(*Config){
+		"label": func(c *Config) {},
+		"mask":  func(c *Config) { c.Mode = ModeMask },
+		"token": func(c *Config) { c.Mode, c.TokenKey = ModeToken, []byte("key") },
+		"fpe":   func(c *Config) { c.Mode, c.FPEKey = ModeFPE, make([]byte, 32) },
+		"age":   func(c *Config) { c.EnabledPatterns = map[string]bool{"SSN": true, "CC": true, "AGE": true} },
+	}
+	for name, configure := range configs {
+		config := DefaultConfig()
+		configure(&config)
+		engine := NewRedactionEngine(config)
+
+		input := append(chunks, Chunk{UUID: "id5", Speaker: "B", Text: "she is aged 95"})
+		result, _ := engine.Process(input)
+		if leaks := engine.VerifyRedacted(result); len(leaks) != 0 {
+			t.Errorf("Config %s: expected no leaks, got %+v", name, leaks)
+		}
+	}
+
+	// A first pass that misses the card number is caught by a full second pass
+	config := DefaultConfig()
+	config.EnabledPatterns = map[string]bool{"SSN": true, "EMAIL": true}
+	result, _ := NewRedactionEngine(config).Process(chunks)
+
+	leaks := NewRedactionEngine(DefaultConfig()).VerifyRedacted(result)
+	if len(leaks) != 1 || leaks[0].UUID != "id2" {
+		t.Errorf("Expected chunk id2 flagged, got %+v", leaks)
+	}
+
+	// So is a value the first pass was told to skip
+	config = DefaultConfig()
+	config.OnMatch = func(patternName, value string, start, end int, full string) bool {
+		return value != "123-45-6789"
+	}
+	result, _ = NewRedactionEngine(config).Process(chunks)
+	leaks = NewRedactionEngine(DefaultConfig()).VerifyRedacted(result)
+	if len(leaks) != 1 || leaks[0].UUID != "id1" {
+		t.Errorf("Expected chunk id1 flagged, got %+v", leaks)
+	}
+}
+
+// TestRedactionEngine_VerifyRedactedDetectorFailed tests that chunks that
+// cannot be verified are flagged
+func TestRedactionEngine_VerifyRedactedDetectorFailed(t *testing.T) {
+	config := DefaultConfig()
+	config.Detectors = []Detector{failingDetector{}}
+	engine := NewRedactionEngine(config)
+
+	chunks := []Chunk{
+		{UUID: "id1", Speaker: "A", Text: "SSN [SSN]"},
+		{UUID: "id2", Speaker: "A", Text: "fail: Jane Roe"},
+	}
+	if leaks := engine.VerifyRedacted(chunks); len(leaks) != 1 || leaks[0].UUID != "id2" {
+		t.Errorf("Expected chunk id2 flagged, got %+v", leaks)
+	}
+}
@@ -0,0 +1,72 @@
+package piiredact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// vttTimingLine matches a WebVTT or SRT cue timing line such as
+// "00:01:02.500 --> 00:01:04.000" or "00:01:02,500 --> 00:01:04,000".
+var vttTimingLine = regexp.MustCompile(`^\s*(?:\d+:)?\d{2}:\d{2}[.,]\d{3}\s+-->\s+(?:\d+:)?\d{2}:\d{2}[.,]\d{3}`)
+
+// RedactVTT redacts the caption text of a WebVTT or SRT subtitle file.
+//
+// Only the text lines that follow a valid cue timing line are redacted.
+// Headers, cue identifiers, SRT index numbers, timing lines, NOTE blocks
+// and blank lines are copied through byte-for-byte, as are malformed cues
+// whose timing line cannot be recognized. Line endings are preserved.
+func (e *RedactionEngine) RedactVTT(r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+
+	inCue := false // Whether the current line belongs to a cue's text
+	cue := 0       // Number of cues seen, used to label chunks in logs
+
+	for {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			body, ending := splitLineEnding(line)
+
+			switch {
+			case strings.TrimSpace(body) == "":
+				// A blank line terminates the current cue
+				inCue = false
+			case vttTimingLine.MatchString(body):
+				// Text lines after a timing line are caption text
+				inCue = true
+				cue++
+			case inCue:
+				e.configMu.RLock()
+				body = e.redactChunk(Chunk{UUID: fmt.Sprintf("cue-%d", cue), Text: body}).Text
+				e.configMu.RUnlock()
+			}
+
+			if _, werr := bw.WriteString(body + ending); werr != nil {
+				return werr
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// splitLineEnding separates a line from its trailing "\n" or "\r\n".
+func splitLineEnding(line string) (string, string) {
+	if strings.HasSuffix(line, "\r\n") {
+		return line[:len(line)-2], "\r\n"
+	}
+	if strings.HasSuffix(line, "\n") {
+		return line[:len(line)-1], "\n"
+	}
+	return line, ""
+}
@@ -0,0 +1,77 @@
+package piiredact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRedactVTT tests that only caption text is redacted in WebVTT files
+func TestRedactVTT(t *testing.T) {
+	input := "WEBVTT\n" +
+		"\n" +
+		"1\n" +
+		"00:00:01.000 --> 00:00:04.000\n" +
+		"My SSN is 123-45-6789\n" +
+		"\n" +
+		"NOTE call back 555-123-4567\n" +
+		"\n" +
+		"2\n" +
+		"00:00:05.000 --> 00:00:07.500\n" +
+		"Email me at user@example.com\n" +
+		"or call 555-123-4567\n"
+
+	expected := "WEBVTT\n" +
+		"\n" +
+		"1\n" +
+		"00:00:01.000 --> 00:00:04.000\n" +
+		"My SSN is [SSN]\n" +
+		"\n" +
+		"NOTE call back 555-123-4567\n" +
+		"\n" +
+		"2\n" +
+		"00:00:05.000 --> 00:00:07.500\n" +
+		"Email me at [EMAIL]\n" +
+		"or call [PHONE]\n"
+
+	engine := NewRedactionEngine(DefaultConfig())
+
+	var out bytes.Buffer
+	if err := engine.RedactVTT(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("RedactVTT returned error: %v", err)
+	}
+
+	if out.String() != expected {
+		t.Errorf("VTT redaction failed:\nExpected: %q\nGot: %q", expected, out.String())
+	}
+}
+
+// TestRedactVTT_SRT tests SRT files with CRLF line endings and a malformed cue
+func TestRedactVTT_SRT(t *testing.T) {
+	input := "1\r\n" +
+		"00:00:01,000 --> 00:00:02,000\r\n" +
+		"Call me at 555-123-4567\r\n" +
+		"\r\n" +
+		"2\r\n" +
+		"00:00:03 -> 00:00:04\r\n" +
+		"Call me at 555-123-4567\r\n"
+
+	expected := "1\r\n" +
+		"00:00:01,000 --> 00:00:02,000\r\n" +
+		"Call me at [PHONE]\r\n" +
+		"\r\n" +
+		"2\r\n" +
+		"00:00:03 -> 00:00:04\r\n" +
+		"Call me at 555-123-4567\r\n"
+
+	engine := NewRedactionEngine(DefaultConfig())
+
+	var out bytes.Buffer
+	if err := engine.RedactVTT(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("RedactVTT returned error: %v", err)
+	}
+
+	if out.String() != expected {
+		t.Errorf("SRT redaction failed:\nExpected: %q\nGot: %q", expected, out.String())
+	}
+}